@@ -3,11 +3,17 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jack/yapper/go-note/internal/config"
 	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/core/watcher"
 	"github.com/jack/yapper/go-note/internal/logging"
+	"github.com/jack/yapper/go-note/internal/lsp"
+	"github.com/jack/yapper/go-note/internal/progress"
+	"github.com/jack/yapper/go-note/internal/rpc"
 	"github.com/jack/yapper/go-note/internal/server"
+	"github.com/jack/yapper/go-note/internal/server/replay"
 )
 
 func main() {
@@ -17,22 +23,126 @@ func main() {
 		os.Exit(1)
 	}
 	logging.SetLevel(cfg.LogLevel)
-	logging.Infof("starting note-daemon (vault: %s)", cfg.VaultPath)
 
-	vault := core.NewFileSystemVault(cfg.VaultPath)
-	index := core.NewInMemoryIndex()
-	parser := core.NewRegexMarkdownParser()
+	var broadcaster *server.Broadcaster
+	if cfg.Watch {
+		broadcaster = server.NewBroadcaster()
+	}
+
+	registry := core.NewWorkspaceRegistry(cfg.DefaultNotebook)
+
+	notebooks := cfg.Notebooks
+	if len(notebooks) == 0 {
+		// Single-vault mode: the vault at cfg.VaultPath is the one and only
+		// (and therefore default) notebook.
+		notebooks = []config.Notebook{{Name: "default", Path: cfg.VaultPath}}
+	}
+
+	for _, nb := range notebooks {
+		domain, err := openNotebook(cfg, nb, broadcaster)
+		if err != nil {
+			logging.Errorf("opening notebook %q at %s failed: %v", nb.Name, nb.Path, err)
+			os.Exit(1)
+		}
+		registry.Open(nb.Name, domain)
+	}
+	logging.Infof("started note-daemon with notebooks: %v (default: %s)", registry.List(), registry.DefaultName())
+
+	open := func(path string) (*core.Domain, error) {
+		return openNotebook(cfg, config.Notebook{Path: path}, broadcaster)
+	}
+
+	var recorder server.Recorder
+	if cfg.RecordPath != "" {
+		f, err := os.OpenFile(cfg.RecordPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			logging.Errorf("opening recording file %s: %v", cfg.RecordPath, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		recorder = replay.NewRecorder(f)
+		logging.Infof("recording stdio traffic to %s", cfg.RecordPath)
+	}
+
+	run := func() error { return server.Run(registry, broadcaster, open, recorder) }
+	if cfg.LSP {
+		logging.Infof("starting in LSP mode")
+		defaultWS, err := registry.Get("")
+		if err != nil {
+			logging.Errorf("LSP mode requires a default notebook: %v", err)
+			os.Exit(1)
+		}
+		run = func() error { return lsp.Run(defaultWS.Domain) }
+	}
+	if err := run(); err != nil {
+		logging.Errorf("server error: %v", err)
+		os.Exit(1)
+	}
+}
+
+// openNotebook builds the Vault/IndexStore/parser/Domain for a single
+// notebook and, if cfg.Watch is set, starts a live watcher feeding
+// broadcaster. Notebooks opened after startup (via workspace/open) go
+// through the same path as those listed in the config file.
+func openNotebook(cfg config.Config, nb config.Notebook, broadcaster *server.Broadcaster) (*core.Domain, error) {
+	vault := core.NewFileSystemVault(nb.Path)
+
+	var index core.IndexStore
+	if cfg.IndexBackend == "sqlite" {
+		indexPath := cfg.IndexPath
+		if nb.Name != "" && nb.Name != "default" {
+			indexPath = fmt.Sprintf("%s-%s", indexPath, sanitizeForPath(nb.Name))
+		}
+		sqliteIndex, err := core.NewSQLiteIndex(indexPath)
+		if err != nil {
+			return nil, fmt.Errorf("opening sqlite index %s: %w", indexPath, err)
+		}
+		index = sqliteIndex
+	} else {
+		index = core.NewInMemoryIndex()
+	}
+
+	var parser core.NoteParser = core.NewRegexMarkdownParser()
+	if cfg.ParserEngine == "ast" {
+		parser = core.NewASTNoteParser()
+	}
 	manager := core.NewVaultIndexManager(vault, index, parser)
 	domain := core.NewDomain(manager)
 
-	if err := domain.ReindexAll(); err != nil {
-		logging.Errorf("initial vault reindex failed: %v", err)
+	var reporter core.ProgressReporter = core.NoOpProgress{}
+	if progress.IsTTY(os.Stderr) {
+		reporter = progress.NewTerminalReporter()
+	}
+	if err := domain.ReindexAllWithProgress(reporter); err != nil {
+		logging.Errorf("initial reindex of notebook %q failed: %v", nb.Name, err)
 	} else {
-		logging.Infof("vault reindex completed")
+		logging.Infof("notebook %q reindexed", nb.Name)
 	}
 
-	if err := server.Run(domain); err != nil {
-		logging.Errorf("server error: %v", err)
-		os.Exit(1)
+	if cfg.Watch {
+		w, err := watcher.New(manager, func(path string, kind watcher.ChangeKind) {
+			broadcaster.Publish(rpc.NewNotification("note/changed", rpc.NoteChangedParams{
+				NoteID: path,
+				Kind:   string(kind),
+			}))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("starting watcher: %w", err)
+		}
+		go w.Start()
+		logging.Infof("watching notebook %q for changes", nb.Name)
 	}
+
+	return domain, nil
+}
+
+func sanitizeForPath(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
 }
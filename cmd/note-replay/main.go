@@ -0,0 +1,99 @@
+// Command note-replay re-drives a recording produced by `note-daemon
+// --record` against a fresh core.Domain and reports whether the daemon
+// still reproduces the recorded responses. It's meant for reproducing a
+// user session from a bug report: run the daemon with --record enabled
+// during the session, then replay the log against a built-from-source
+// daemon to see what changed.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/server/replay"
+)
+
+func main() {
+	var recordingPath, vaultPath, onlyMethod, speed string
+	var maskFields []string
+
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--recording":
+			i++
+			recordingPath = argAt(args, i)
+		case "--vault":
+			i++
+			vaultPath = argAt(args, i)
+		case "--only-method":
+			i++
+			onlyMethod = argAt(args, i)
+		case "--speed":
+			i++
+			speed = argAt(args, i)
+		case "--mask":
+			i++
+			maskFields = append(maskFields, argAt(args, i))
+		default:
+			fmt.Fprintf(os.Stderr, "unrecognized argument %q. Usage: %s\n", args[i], usage())
+			os.Exit(1)
+		}
+	}
+	if recordingPath == "" || vaultPath == "" {
+		fmt.Fprintln(os.Stderr, usage())
+		os.Exit(1)
+	}
+
+	opts := replay.Options{OnlyMethod: onlyMethod}
+	if speed == "realtime" {
+		opts.Speed = replay.SpeedRealtime
+	}
+	if len(maskFields) == 0 {
+		maskFields = []string{"timestamp", "created_at", "updated_at"}
+	}
+
+	f, err := os.Open(recordingPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "opening recording: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	vault := core.NewFileSystemVault(vaultPath)
+	manager := core.NewVaultIndexManager(vault, core.NewInMemoryIndex(), core.NewRegexMarkdownParser())
+	domain := core.NewDomain(manager)
+	if err := domain.ReindexAll(); err != nil {
+		fmt.Fprintf(os.Stderr, "reindexing replay vault: %v\n", err)
+		os.Exit(1)
+	}
+	registry := core.NewWorkspaceRegistry("default")
+	registry.Open("default", domain)
+
+	summary, err := replay.Replay(f, registry, opts, maskFields)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("matched: %d, mismatched: %d, skipped: %d\n", summary.Matched, len(summary.Mismatched), summary.Skipped)
+	for _, m := range summary.Mismatched {
+		fmt.Printf("  seq %d (%s):\n    expected: %s\n    actual:   %s\n", m.Seq, m.Method, m.Expected, m.Actual)
+	}
+	if !summary.OK() {
+		os.Exit(1)
+	}
+}
+
+func argAt(args []string, i int) string {
+	if i >= len(args) {
+		fmt.Fprintln(os.Stderr, usage())
+		os.Exit(1)
+	}
+	return args[i]
+}
+
+func usage() string {
+	return "note-replay --recording PATH --vault PATH [--only-method NAME] [--speed fast|realtime] [--mask FIELD ...]"
+}
@@ -0,0 +1,83 @@
+// Command yapper-lsp runs internal/langserver's Server over stdio, the
+// transport every LSP-speaking editor expects. It lives as its own binary
+// rather than a gen0 cobra subcommand because cmd/yapper.go's CLI has no
+// existing bridge into gen1's internal/index, internal/vault, or
+// internal/parser packages; note-daemon and note-replay (a different
+// generation of this codebase) establish the precedent of one binary per
+// entry point rather than one do-everything command.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/langserver"
+	"github.com/weakphish/yapper/internal/parser"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+func main() {
+	vaultPath := flag.String("vault", "", "path to the vault directory (required)")
+	vaultName := flag.String("vault-name", "default", "name the vault is registered under")
+	storeKind := flag.String("store", "memory", "index store backend: memory or sqlite")
+	dbPath := flag.String("db", "", "path to the SQLite database file (required when -store=sqlite)")
+	engine := flag.String("parser", "", "note parser engine: regex (default) or goldmark")
+	flag.Parse()
+
+	if err := run(*vaultPath, *vaultName, *storeKind, *dbPath, *engine); err != nil {
+		fmt.Fprintf(os.Stderr, "yapper-lsp: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(vaultPath, vaultName, storeKind, dbPath, engine string) error {
+	if vaultPath == "" {
+		return fmt.Errorf("-vault is required")
+	}
+
+	v, err := vault.NewNamedFileSystemVault(vaultName, vaultPath)
+	if err != nil {
+		return fmt.Errorf("open vault %q: %w", vaultPath, err)
+	}
+
+	var store index.IndexStore
+	switch storeKind {
+	case "", "memory":
+		store = index.NewInMemoryIndexStore()
+	case "sqlite":
+		if dbPath == "" {
+			return fmt.Errorf("-db is required when -store=sqlite")
+		}
+		store, err = index.NewSQLiteIndexStore(dbPath)
+		if err != nil {
+			return fmt.Errorf("open SQLite store %q: %w", dbPath, err)
+		}
+	default:
+		return fmt.Errorf("unknown -store %q: want memory or sqlite", storeKind)
+	}
+
+	p, err := parser.NewNoteParser(parser.Engine(engine))
+	if err != nil {
+		return fmt.Errorf("build parser: %w", err)
+	}
+
+	manager, err := index.NewVaultIndexManager(v, p, store)
+	if err != nil {
+		return fmt.Errorf("build index manager: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := manager.FullReindex(ctx); err != nil {
+		return fmt.Errorf("initial reindex of %q: %w", vaultPath, err)
+	}
+
+	registry := index.NewRegistry()
+	if err := registry.Register(vaultName, v, manager, store); err != nil {
+		return fmt.Errorf("register vault %q: %w", vaultName, err)
+	}
+
+	return langserver.NewServer(registry).Run(ctx, os.Stdin, os.Stdout)
+}
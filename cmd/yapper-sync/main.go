@@ -0,0 +1,92 @@
+// Command yapper-sync runs a one-shot push or pull between the vault's task
+// index and a remote issue tracker. It lives as its own binary for the same
+// reason cmd/yapper-lsp does: cmd/yapper.go's CLI has no bridge into gen1's
+// internal/index/internal/vault/internal/parser stack, only into the
+// gorm-backed internal/model and internal/db used by its task/note
+// commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/sync"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+func main() {
+	vaultPath := flag.String("vault", "", "path to the vault directory (required)")
+	storeKind := flag.String("store", "memory", "index store backend: memory or sqlite")
+	dbPath := flag.String("db", "", "path to the SQLite database file (required when -store=sqlite)")
+	baseURL := flag.String("gitea-url", "", "base URL of the Gitea/Forgejo instance (required)")
+	token := flag.String("gitea-token", os.Getenv("YAPPER_GITEA_TOKEN"), "Gitea/Forgejo access token (default: $YAPPER_GITEA_TOKEN)")
+	owner := flag.String("gitea-owner", "", "repository owner (required)")
+	repo := flag.String("gitea-repo", "", "repository name (required)")
+	remoteName := flag.String("remote-name", "gitea", "name this remote is recorded as in Task.Origin")
+	flag.Parse()
+
+	if flag.NArg() != 1 || (flag.Arg(0) != "push" && flag.Arg(0) != "pull") {
+		fmt.Fprintln(os.Stderr, "usage: yapper-sync [flags] push|pull")
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *vaultPath, *storeKind, *dbPath, *baseURL, *token, *owner, *repo, *remoteName); err != nil {
+		fmt.Fprintf(os.Stderr, "yapper-sync: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(verb, vaultPath, storeKind, dbPath, baseURL, token, owner, repo, remoteName string) error {
+	if vaultPath == "" {
+		return fmt.Errorf("-vault is required")
+	}
+	if baseURL == "" || owner == "" || repo == "" {
+		return fmt.Errorf("-gitea-url, -gitea-owner, and -gitea-repo are required")
+	}
+
+	if _, err := vault.NewFileSystemVault(vaultPath); err != nil {
+		return fmt.Errorf("open vault %q: %w", vaultPath, err)
+	}
+
+	var store index.IndexStore
+	var err error
+	switch storeKind {
+	case "", "memory":
+		store = index.NewInMemoryIndexStore()
+	case "sqlite":
+		if dbPath == "" {
+			return fmt.Errorf("-db is required when -store=sqlite")
+		}
+		store, err = index.NewSQLiteIndexStore(dbPath)
+		if err != nil {
+			return fmt.Errorf("open SQLite store %q: %w", dbPath, err)
+		}
+	default:
+		return fmt.Errorf("unknown -store %q: want memory or sqlite", storeKind)
+	}
+
+	backend, err := sync.NewGiteaTaskRepository(sync.GiteaConfig{
+		RemoteName: remoteName,
+		BaseURL:    baseURL,
+		Token:      token,
+		Owner:      owner,
+		Repo:       repo,
+	})
+	if err != nil {
+		return fmt.Errorf("configure gitea backend: %w", err)
+	}
+
+	syncer, err := sync.NewSyncer(backend, store)
+	if err != nil {
+		return fmt.Errorf("create syncer: %w", err)
+	}
+
+	ctx := context.Background()
+	if verb == "push" {
+		return syncer.Push(ctx)
+	}
+	return syncer.Pull(ctx)
+}
@@ -30,6 +30,44 @@ func Execute() {
 		Run:   cli.AddTaskCmd,
 	}
 	taskCmd.AddCommand(addTaskCmd)
+
+	pickTaskCmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Fuzzy-find a task with fzf",
+		Run:   cli.PickTaskCmd,
+	}
+	taskCmd.AddCommand(pickTaskCmd)
+
+	startTaskCmd := &cobra.Command{
+		Use:   "start [task id]",
+		Short: "Move a task to in-progress, if all its dependencies are completed",
+		Args:  cobra.ExactArgs(1),
+		Run:   cli.StartTaskCmd,
+	}
+	taskCmd.AddCommand(startTaskCmd)
+
+	completeTaskCmd := &cobra.Command{
+		Use:   "complete [task id]",
+		Short: "Mark a task completed",
+		Args:  cobra.ExactArgs(1),
+		Run:   cli.CompleteTaskCmd,
+	}
+	taskCmd.AddCommand(completeTaskCmd)
+
+	watchTaskCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch for recurring tasks whose next occurrence has come due",
+		Run:   cli.WatchTasksCmd,
+	}
+	taskCmd.AddCommand(watchTaskCmd)
+
+	pomodoroTaskCmd := &cobra.Command{
+		Use:   "pomodoro [task id]",
+		Short: "Run a pomodoro work/break session against a task",
+		Args:  cobra.ExactArgs(1),
+		Run:   cli.PomodoroTaskCmd,
+	}
+	taskCmd.AddCommand(pomodoroTaskCmd)
 	rootCmd.AddCommand(taskCmd)
 
 	// Define note command and its subcommands
@@ -45,6 +83,13 @@ func Execute() {
 		Run:   cli.AddNoteCmd,
 	}
 	noteCmd.AddCommand(addNoteCmd)
+
+	pickNoteCmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Fuzzy-find a note with fzf",
+		Run:   cli.PickNoteCmd,
+	}
+	noteCmd.AddCommand(pickNoteCmd)
 	rootCmd.AddCommand(noteCmd)
 
 	if err := fang.Execute(context.TODO(), rootCmd); err != nil {
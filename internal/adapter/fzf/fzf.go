@@ -0,0 +1,108 @@
+// Package fzf shells out to the fzf(1) binary to give the gorm-backed forms
+// in internal/render a fast fuzzy picker, falling back to the existing huh
+// prompts wherever fzf isn't installed.
+package fzf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Available reports whether fzf is on $PATH.
+func Available() bool {
+	_, err := exec.LookPath("fzf")
+	return err == nil
+}
+
+// Candidate is one selectable line: Key is the hidden token returned to the
+// caller on selection, Label is what's shown in the list, and Preview is
+// rendered in fzf's preview pane.
+type Candidate struct {
+	Key     string
+	Label   string
+	Preview string
+}
+
+// Pick runs fzf over candidates and returns the Key of the selected one. It
+// returns an empty key and a nil error if the user aborts the picker (Esc or
+// Ctrl-C), matching how huh.Form.Run's cancellation is already handled by
+// callers in this package.
+func Pick(prompt string, candidates []Candidate) (string, error) {
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	var input bytes.Buffer
+	for _, c := range candidates {
+		fmt.Fprintf(&input, "%s\t%s\t%s\n", c.Key, c.Label, c.Preview)
+	}
+
+	cmd := exec.Command("fzf",
+		"--prompt", prompt+"> ",
+		"--delimiter", "\t",
+		"--with-nth", "2",
+		"--preview", "echo {3}",
+	)
+	cmd.Stdin = &input
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 130 {
+			// User cancelled the picker.
+			return "", nil
+		}
+		return "", fmt.Errorf("running fzf: %w", err)
+	}
+
+	line := strings.TrimRight(string(out), "\n")
+	if line == "" {
+		return "", nil
+	}
+	key, _, _ := strings.Cut(line, "\t")
+	return key, nil
+}
+
+// PickMulti is Pick with fzf's --multi enabled (Tab toggles a selection),
+// returning the Key of every selected candidate in fzf's display order.
+func PickMulti(prompt string, candidates []Candidate) ([]string, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var input bytes.Buffer
+	for _, c := range candidates {
+		fmt.Fprintf(&input, "%s\t%s\t%s\n", c.Key, c.Label, c.Preview)
+	}
+
+	cmd := exec.Command("fzf",
+		"--prompt", prompt+"> ",
+		"--multi",
+		"--delimiter", "\t",
+		"--with-nth", "2",
+		"--preview", "echo {3}",
+	)
+	cmd.Stdin = &input
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 130 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("running fzf: %w", err)
+	}
+
+	var keys []string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(line, "\t")
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+	"github.com/weakphish/yapper/internal/adapter/fzf"
 	"github.com/weakphish/yapper/internal/db"
 	"github.com/weakphish/yapper/internal/render"
+	model "github.com/weakphish/yapper/internal/taskmodel"
 	"golang.org/x/exp/slog"
 )
 
@@ -44,3 +48,45 @@ func AddNoteCmd(cmd *cobra.Command, args []string) {
 	}
 	slog.Info("Note inserted into database", "note", n)
 }
+
+// PickNoteCmd fuzzy-picks a note with fzf and prints its content.
+func PickNoteCmd(cmd *cobra.Command, args []string) {
+	if !fzf.Available() {
+		slog.Error("fzf is not installed or not on $PATH")
+		return
+	}
+
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("error getting database connection", "error", err)
+		panic(err)
+	}
+
+	var allNotes []model.Note
+	if result := d.Find(&allNotes); result.Error != nil {
+		slog.Error("Could not get notes from database", "error", result.Error)
+		return
+	}
+
+	candidates := make([]fzf.Candidate, len(allNotes))
+	for i, note := range allNotes {
+		candidates[i] = fzf.Candidate{
+			Key:     fmt.Sprintf("%d", note.ID),
+			Label:   note.Title,
+			Preview: note.Content,
+		}
+	}
+
+	key, err := fzf.Pick("note", candidates)
+	if err != nil {
+		slog.Error("Error running fzf note picker", "error", err)
+		return
+	}
+	if key == "" {
+		return
+	}
+
+	var picked model.Note
+	d.Where("id = ?", key).Find(&picked)
+	fmt.Printf("%s\n%s\n", picked.Title, picked.Content)
+}
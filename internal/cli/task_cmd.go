@@ -1,10 +1,19 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
 	"github.com/spf13/cobra"
+	"github.com/weakphish/yapper/internal/adapter/fzf"
 	"github.com/weakphish/yapper/internal/db"
-	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/graph"
+	"github.com/weakphish/yapper/internal/pomodoro"
 	"github.com/weakphish/yapper/internal/render"
+	"github.com/weakphish/yapper/internal/scheduler"
+	model "github.com/weakphish/yapper/internal/taskmodel"
 	"golang.org/x/exp/slog"
 )
 
@@ -45,3 +54,176 @@ func AddTaskCmd(cmd *cobra.Command, args []string) {
 	t := render.AddTaskForm(title, allTasksInDb)
 	slog.Info("Task created", "task", t)
 }
+
+// PickTaskCmd fuzzy-picks a task with fzf and prints its details.
+func PickTaskCmd(cmd *cobra.Command, args []string) {
+	if !fzf.Available() {
+		slog.Error("fzf is not installed or not on $PATH")
+		return
+	}
+
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("error getting database connection", "error", err)
+		panic(err)
+	}
+
+	var allTasks []model.Task
+	if result := d.Find(&allTasks); result.Error != nil {
+		slog.Error("Could not get tasks from database", "error", result.Error)
+		return
+	}
+
+	candidates := make([]fzf.Candidate, len(allTasks))
+	for i, task := range allTasks {
+		candidates[i] = fzf.Candidate{
+			Key:     task.ID,
+			Label:   fmt.Sprintf("%s [%v]", task.Title, task.Status),
+			Preview: task.Description,
+		}
+	}
+
+	id, err := fzf.Pick("task", candidates)
+	if err != nil {
+		slog.Error("Error running fzf task picker", "error", err)
+		return
+	}
+	if id == "" {
+		return
+	}
+
+	var picked model.Task
+	d.Where(&model.Task{ID: id}).Find(&picked)
+	fmt.Printf("%s [%v]\n%s\n", picked.Title, picked.Status, picked.Description)
+}
+
+// StartTaskCmd transitions the task with the given ID to InProgress. It
+// refuses the transition if any of the task's dependencies aren't Completed
+// yet, per graph.Ready.
+func StartTaskCmd(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		slog.Error("No task ID provided", "args", args)
+		cmd.Help()
+		return
+	}
+	id := args[0]
+
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("error getting database connection", "error", err)
+		panic(err)
+	}
+
+	var task model.Task
+	if result := d.Preload("DependsOn").Where(&model.Task{ID: id}).First(&task); result.Error != nil {
+		slog.Error("Could not find task", "id", id, "error", result.Error)
+		return
+	}
+
+	if !graph.Ready(&task) {
+		slog.Error("Cannot start task: a dependency is not yet Completed", "id", id, "title", task.Title)
+		return
+	}
+
+	now := time.Now()
+	task.Status = model.InProgress
+	task.StartedAt = &now
+	if result := d.Save(&task); result.Error != nil {
+		slog.Error("Could not update task", "id", id, "error", result.Error)
+	}
+}
+
+// CompleteTaskCmd marks the task with the given ID Completed.
+func CompleteTaskCmd(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		slog.Error("No task ID provided", "args", args)
+		cmd.Help()
+		return
+	}
+	id := args[0]
+
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("error getting database connection", "error", err)
+		panic(err)
+	}
+
+	var task model.Task
+	if result := d.Where(&model.Task{ID: id}).First(&task); result.Error != nil {
+		slog.Error("Could not find task", "id", id, "error", result.Error)
+		return
+	}
+
+	next := task.Complete()
+	if result := d.Save(&task); result.Error != nil {
+		slog.Error("Could not update task", "id", id, "error", result.Error)
+		return
+	}
+	if next != nil {
+		if result := d.Create(next); result.Error != nil {
+			slog.Error("Could not create next occurrence", "id", id, "error", result.Error)
+			return
+		}
+		slog.Info("Scheduled next occurrence", "title", next.Title, "due", next.CreatedAt)
+	}
+}
+
+// WatchTasksCmd runs the recurrence scheduler in the foreground, printing
+// each task as its next occurrence comes due, until interrupted.
+func WatchTasksCmd(cmd *cobra.Command, args []string) {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	slog.Info("Watching for due tasks", "interval", watchInterval)
+	scheduler.Run(ctx, watchInterval, func(t *model.Task) {
+		fmt.Printf("due: %s [%s]\n", t.Title, t.ID)
+	})
+}
+
+// watchInterval is how often WatchTasksCmd polls for newly due tasks.
+const watchInterval = time.Minute
+
+// PomodoroTaskCmd runs a pomodoro.Session against the task with the given
+// ID in the foreground, printing each phase change, until interrupted.
+func PomodoroTaskCmd(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		slog.Error("No task ID provided", "args", args)
+		cmd.Help()
+		return
+	}
+	id := args[0]
+
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("error getting database connection", "error", err)
+		panic(err)
+	}
+
+	var task model.Task
+	if result := d.Where(&model.Task{ID: id}).First(&task); result.Error != nil {
+		slog.Error("Could not find task", "id", id, "error", result.Error)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	session := pomodoro.New(&task, pomodoro.DefaultConfig())
+	go func() {
+		for event := range session.Events() {
+			if event.Done {
+				fmt.Printf("%s complete\n", event.Phase)
+				continue
+			}
+			fmt.Printf("\r%s: %s remaining", event.Phase, event.Remaining.Round(time.Second))
+		}
+	}()
+
+	if err := session.Run(ctx); err != nil && ctx.Err() == nil {
+		slog.Error("Pomodoro session ended with error", "error", err)
+	}
+
+	if result := d.Save(&task); result.Error != nil {
+		slog.Error("Could not save task's time entries", "id", id, "error", result.Error)
+	}
+}
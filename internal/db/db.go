@@ -4,10 +4,16 @@ import (
 	"fmt"
 
 	"github.com/glebarez/sqlite" // Pure go SQLite driver, checkout https://github.com/glebarez/sqlite for details
-	"github.com/weakphish/yapper/internal/model"
+	"github.com/spf13/afero"
+	"github.com/weakphish/yapper/internal/files"
+	model "github.com/weakphish/yapper/internal/taskmodel"
 	"gorm.io/gorm"
 )
 
+// attachmentsDir holds attachment blobs alongside the sqlite file, the same
+// way yap.db itself lives in the working directory.
+const attachmentsDir = "yap_attachments"
+
 func InitDB() (*gorm.DB, error) {
 	// TODO: configure database path
 	db, err := gorm.Open(sqlite.Open("yap.db"), &gorm.Config{})
@@ -15,7 +21,11 @@ func InitDB() (*gorm.DB, error) {
 		return nil, fmt.Errorf("error initializing database: %w", err)
 	}
 
-	db.AutoMigrate(&model.Task{}, &model.Note{})
+	db.AutoMigrate(&model.Task{}, &model.Note{}, &files.File{}, &model.TimeEntry{})
+
+	if model.AttachmentStore == nil {
+		model.AttachmentStore = files.NewStore(afero.NewOsFs(), attachmentsDir, files.DefaultMaxSize)
+	}
 
 	return db, nil
 }
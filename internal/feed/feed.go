@@ -0,0 +1,155 @@
+// Package feed renders recent vault activity as an Atom 1.0 document, so a
+// vault's log entries and task transitions can be subscribed to from any
+// feed reader. The XML is hand-written with encoding/xml rather than
+// pulling in a syndication library, matching this repo's preference for
+// small, direct implementations over heavier dependencies.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jack/yapper/go-note/internal/core"
+)
+
+// ActivitySource is the slice of core.Domain that BuildFeed needs. It's
+// satisfied by *core.Domain regardless of which IndexStore backs it
+// (in-memory or SQLite), so the feed never depends on a storage backend
+// directly.
+type ActivitySource interface {
+	NotesInRange(r *core.DateRange) []core.NoteMeta
+	WeeklySummary(r *core.DateRange) core.WeeklySummary
+	LogEntriesForNote(id core.NoteID) []core.LogEntry
+}
+
+// Feed is the root Atom element.
+type Feed struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Entry is one Atom <entry>.
+type Entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    string     `xml:"updated"`
+	Categories []Category `xml:"category"`
+	Content    Content    `xml:"content"`
+}
+
+// Category is an Atom <category term="...">.
+type Category struct {
+	Term string `xml:"term,attr"`
+}
+
+// Content is an Atom <content type="html">, holding escaped HTML text.
+type Content struct {
+	Type string `xml:",attr"`
+	Body string `xml:",chardata"`
+}
+
+// Build renders an Atom feed covering every log entry and task
+// created/completed transition whose timestamp falls within [start, end].
+// title names the vault the feed describes (e.g. its root path), and
+// feedID is a stable identifier for the <id> element (e.g. the vault path
+// prefixed with a urn scheme).
+func Build(source ActivitySource, start, end core.Date, title, feedID string) (string, error) {
+	r := &core.DateRange{Start: start, End: end}
+	summary := source.WeeklySummary(r)
+	notes := source.NotesInRange(r)
+
+	var entries []Entry
+	for _, task := range summary.NewTasks {
+		entries = append(entries, taskEntry(task, task.CreatedAt, "created"))
+	}
+	for _, task := range summary.CompletedTasks {
+		if task.ClosedAt == nil {
+			continue
+		}
+		entries = append(entries, taskEntry(task, *task.ClosedAt, "completed"))
+	}
+	for _, note := range notes {
+		for _, entry := range source.LogEntriesForNote(note.ID) {
+			entries = append(entries, logEntry(note, entry))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Updated > entries[j].Updated })
+
+	feed := Feed{
+		Title:   title,
+		ID:      feedID,
+		Updated: latestUpdated(entries),
+		Entries: entries,
+	}
+
+	out, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshaling feed: %w", err)
+	}
+	return xml.Header + string(out), nil
+}
+
+func taskEntry(task core.Task, when time.Time, transition string) Entry {
+	entry := Entry{
+		ID:      fmt.Sprintf("urn:yapper:task:%s:%s", task.ID, transition),
+		Title:   fmt.Sprintf("[%s] %s", transition, task.Title),
+		Updated: when.UTC().Format(time.RFC3339),
+		Content: Content{Type: "html", Body: taskContentHTML(task, transition)},
+	}
+	for _, tag := range task.Tags {
+		entry.Categories = append(entry.Categories, Category{Term: tag})
+	}
+	return entry
+}
+
+func taskContentHTML(task core.Task, transition string) string {
+	backlink := ""
+	if task.SourceNoteID != nil {
+		backlink = fmt.Sprintf(" (from <a href=\"yapper://note/%s\">%s</a>)", *task.SourceNoteID, *task.SourceNoteID)
+	}
+	return fmt.Sprintf("<p>Task %s %s: %s</p>%s", task.ID, transition, task.Title, backlink)
+}
+
+func logEntry(note core.NoteMeta, entry core.LogEntry) Entry {
+	updated := time.Time{}
+	if entry.Timestamp != nil {
+		if t, err := time.Parse(time.RFC3339, *entry.Timestamp); err == nil {
+			updated = t
+		}
+	}
+
+	e := Entry{
+		ID:      fmt.Sprintf("urn:yapper:log:%s", entry.ID),
+		Title:   fmt.Sprintf("%s: %s", note.Title, truncate(entry.ContentMD, 60)),
+		Updated: updated.UTC().Format(time.RFC3339),
+		Content: Content{Type: "html", Body: logContentHTML(note, entry)},
+	}
+	for _, tag := range entry.Tags {
+		e.Categories = append(e.Categories, Category{Term: tag})
+	}
+	return e
+}
+
+func logContentHTML(note core.NoteMeta, entry core.LogEntry) string {
+	return fmt.Sprintf("<p>%s</p><p>(from <a href=\"yapper://note/%s\">%s</a>)</p>", entry.ContentMD, note.ID, note.Title)
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "…"
+}
+
+func latestUpdated(entries []Entry) string {
+	if len(entries) == 0 {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+	return entries[0].Updated
+}
@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jack/yapper/go-note/internal/core"
+)
+
+// fakeSource is a minimal ActivitySource a test can populate directly,
+// mirroring the fakeRemote pattern used elsewhere in this repo for
+// interface-backed dependencies.
+type fakeSource struct {
+	summary    core.WeeklySummary
+	notes      []core.NoteMeta
+	logEntries map[core.NoteID][]core.LogEntry
+}
+
+func (f *fakeSource) NotesInRange(r *core.DateRange) []core.NoteMeta     { return f.notes }
+func (f *fakeSource) WeeklySummary(r *core.DateRange) core.WeeklySummary { return f.summary }
+func (f *fakeSource) LogEntriesForNote(id core.NoteID) []core.LogEntry {
+	return f.logEntries[id]
+}
+
+func TestBuildRendersTaskAndLogEntries(t *testing.T) {
+	closedAt := time.Date(2026, time.January, 3, 12, 0, 0, 0, time.UTC)
+	source := &fakeSource{
+		summary: core.WeeklySummary{
+			NewTasks:       []core.Task{{ID: "T-1", Title: "Write the report", CreatedAt: time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC), Tags: []string{"writing"}}},
+			CompletedTasks: []core.Task{{ID: "T-2", Title: "Ship the release", ClosedAt: &closedAt}},
+		},
+		notes: []core.NoteMeta{{ID: "notes/daily.md", Title: "Daily"}},
+		logEntries: map[core.NoteID][]core.LogEntry{
+			"notes/daily.md": {{ID: "log-1", ContentMD: "Paid the bill", Tags: []string{"finance"}}},
+		},
+	}
+
+	start := core.NewDate(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	end := core.NewDate(time.Date(2026, time.January, 7, 0, 0, 0, 0, time.UTC))
+
+	xmlDoc, err := Build(source, start, end, "My Vault", "urn:yapper:notebook:default")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if !strings.HasPrefix(xmlDoc, xml.Header) {
+		t.Fatal("Build() result does not start with the XML header")
+	}
+
+	var feed Feed
+	if err := xml.Unmarshal([]byte(xmlDoc), &feed); err != nil {
+		t.Fatalf("unmarshal Build() output: %v", err)
+	}
+	if feed.Title != "My Vault" {
+		t.Fatalf("feed.Title = %q, want %q", feed.Title, "My Vault")
+	}
+	if feed.ID != "urn:yapper:notebook:default" {
+		t.Fatalf("feed.ID = %q, want %q", feed.ID, "urn:yapper:notebook:default")
+	}
+	if len(feed.Entries) != 3 {
+		t.Fatalf("len(feed.Entries) = %d, want 3 (one per new/completed task, one log entry)", len(feed.Entries))
+	}
+}
+
+func TestBuildSortsEntriesNewestFirst(t *testing.T) {
+	source := &fakeSource{
+		summary: core.WeeklySummary{
+			NewTasks: []core.Task{
+				{ID: "T-1", Title: "Earlier task", CreatedAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: "T-2", Title: "Later task", CreatedAt: time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	xmlDoc, err := Build(source, core.Date{}, core.Date{}, "My Vault", "urn:yapper:notebook:default")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var feed Feed
+	if err := xml.Unmarshal([]byte(xmlDoc), &feed); err != nil {
+		t.Fatalf("unmarshal Build() output: %v", err)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("len(feed.Entries) = %d, want 2", len(feed.Entries))
+	}
+	if !strings.Contains(feed.Entries[0].Title, "Later task") {
+		t.Fatalf("feed.Entries[0].Title = %q, want the newer entry first", feed.Entries[0].Title)
+	}
+}
+
+func TestBuildWithNoActivityStillProducesValidFeed(t *testing.T) {
+	source := &fakeSource{}
+
+	xmlDoc, err := Build(source, core.Date{}, core.Date{}, "Empty Vault", "urn:yapper:notebook:empty")
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var feed Feed
+	if err := xml.Unmarshal([]byte(xmlDoc), &feed); err != nil {
+		t.Fatalf("unmarshal Build() output: %v", err)
+	}
+	if len(feed.Entries) != 0 {
+		t.Fatalf("len(feed.Entries) = %d, want 0", len(feed.Entries))
+	}
+	if feed.Updated == "" {
+		t.Fatal("feed.Updated is empty, want a fallback timestamp")
+	}
+}
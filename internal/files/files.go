@@ -0,0 +1,117 @@
+// Package files implements task attachment storage, modeled on Vikunja's
+// attachments design: metadata (File) is persisted alongside tasks while the
+// binary content lives in a Store backed by an afero.Fs, so the backend can
+// be swapped between a local directory and an in-memory filesystem for tests
+// without touching any calling code.
+package files
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// File records one attachment's metadata. Its binary content lives in a
+// Store, keyed by ID rather than Name, so two attachments sharing a filename
+// never collide.
+type File struct {
+	ID        string
+	TaskID    string
+	Name      string
+	Mime      string
+	Size      int64
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// DefaultMaxSize is the per-attachment size limit NewStore enforces when
+// given maxSize <= 0.
+const DefaultMaxSize = 25 << 20 // 25 MiB, matching Vikunja's own default
+
+// Store persists attachment blobs on fs, keyed by File.ID under root. Pass
+// afero.NewMemMapFs() instead of a local-disk afero.Fs in tests.
+type Store struct {
+	fs      afero.Fs
+	root    string
+	maxSize int64
+}
+
+// NewStore builds a Store rooted at root on fs. maxSize <= 0 falls back to
+// DefaultMaxSize.
+func NewStore(fs afero.Fs, root string, maxSize int64) *Store {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Store{fs: fs, root: root, maxSize: maxSize}
+}
+
+// Save reads r into a new blob keyed by a freshly generated File.ID and
+// returns its metadata. The file is rejected wholesale, not truncated, if it
+// exceeds the Store's maxSize.
+func (s *Store) Save(r io.Reader, taskID, name, mimeType, createdBy string) (*File, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, fmt.Errorf("files: generate attachment id: %w", err)
+	}
+
+	if err := s.fs.MkdirAll(s.root, 0o755); err != nil {
+		return nil, fmt.Errorf("files: create store root %q: %w", s.root, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("files: read attachment %q: %w", name, err)
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, fmt.Errorf("files: attachment %q exceeds max size of %d bytes", name, s.maxSize)
+	}
+
+	if err := afero.WriteFile(s.fs, s.blobPath(id), data, 0o644); err != nil {
+		return nil, fmt.Errorf("files: write attachment %q: %w", name, err)
+	}
+
+	return &File{
+		ID:        id,
+		TaskID:    taskID,
+		Name:      name,
+		Mime:      mimeType,
+		Size:      int64(len(data)),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Open returns a reader over the blob stored for id. The caller must Close it.
+func (s *Store) Open(id string) (io.ReadCloser, error) {
+	f, err := s.fs.Open(s.blobPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("files: open attachment %q: %w", id, err)
+	}
+	return f, nil
+}
+
+// Remove deletes the blob stored for id.
+func (s *Store) Remove(id string) error {
+	if err := s.fs.Remove(s.blobPath(id)); err != nil {
+		return fmt.Errorf("files: remove attachment %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *Store) blobPath(id string) string {
+	return path.Join(s.root, id)
+}
+
+// newID generates a random 128-bit hex attachment ID.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
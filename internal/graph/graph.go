@@ -0,0 +1,132 @@
+// Package graph provides dependency-graph operations over model.Task's
+// DependsOn edges: cycle detection before a new edge is added, topological
+// scheduling, and finding the tasks that are actionable right now.
+package graph
+
+import (
+	"fmt"
+
+	model "github.com/weakphish/yapper/internal/taskmodel"
+)
+
+// color tracks a node's DFS state: white (unvisited), gray (on the current
+// path), or black (fully explored).
+type color int
+
+const (
+	white color = iota
+	gray
+	black
+)
+
+// WouldCreateCycle reports whether adding a dependency edge from task onto
+// dependsOn (i.e. task.DependsOn = append(task.DependsOn, dependsOn)) would
+// introduce a cycle. It runs before the edge exists, walking dependsOn's own
+// DependsOn edges with DFS coloring: reaching task, or reaching a node
+// already gray on the current path, both mean a cycle.
+func WouldCreateCycle(task, dependsOn *model.Task) bool {
+	if task == nil || dependsOn == nil {
+		return false
+	}
+	if task.ID == dependsOn.ID {
+		return true
+	}
+	colors := make(map[string]color)
+	return hasPath(dependsOn, task.ID, colors)
+}
+
+func hasPath(node *model.Task, targetID string, colors map[string]color) bool {
+	if node == nil {
+		return false
+	}
+	if node.ID == targetID {
+		return true
+	}
+	colors[node.ID] = gray
+	for _, dep := range node.DependsOn {
+		switch colors[dep.ID] {
+		case gray:
+			// A back-edge onto the current path: the graph already has a
+			// cycle elsewhere, so treat it as reaching target too.
+			return true
+		case black:
+			continue
+		default:
+			if hasPath(dep, targetID, colors) {
+				return true
+			}
+		}
+	}
+	colors[node.ID] = black
+	return false
+}
+
+// TopoSort orders tasks so every task appears after everything in its
+// DependsOn list, using Kahn's algorithm. Edges to tasks outside the given
+// slice are ignored, since there's nothing to order them against. It returns
+// an error if the dependency graph restricted to tasks contains a cycle.
+func TopoSort(tasks []*model.Task) ([]*model.Task, error) {
+	inDegree := make(map[string]int, len(tasks))
+	dependents := make(map[string][]*model.Task, len(tasks))
+	known := make(map[string]struct{}, len(tasks))
+	for _, t := range tasks {
+		known[t.ID] = struct{}{}
+	}
+	for _, t := range tasks {
+		for _, dep := range t.DependsOn {
+			if _, ok := known[dep.ID]; !ok {
+				continue
+			}
+			inDegree[t.ID]++
+			dependents[dep.ID] = append(dependents[dep.ID], t)
+		}
+	}
+
+	queue := make([]*model.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if inDegree[t.ID] == 0 {
+			queue = append(queue, t)
+		}
+	}
+
+	sorted := make([]*model.Task, 0, len(tasks))
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, next)
+		for _, dependent := range dependents[next.ID] {
+			inDegree[dependent.ID]--
+			if inDegree[dependent.ID] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	if len(sorted) != len(tasks) {
+		return nil, fmt.Errorf("graph: dependency cycle detected among %d task(s)", len(tasks)-len(sorted))
+	}
+	return sorted, nil
+}
+
+// Ready reports whether every task in t.DependsOn is Completed, i.e. t could
+// move to InProgress right now.
+func Ready(t *model.Task) bool {
+	for _, dep := range t.DependsOn {
+		if dep.Status != model.Completed {
+			return false
+		}
+	}
+	return true
+}
+
+// NextActionable returns every Todo task in tasks that's Ready, the set a
+// caller could reasonably suggest starting next.
+func NextActionable(tasks []*model.Task) []*model.Task {
+	var actionable []*model.Task
+	for _, t := range tasks {
+		if t.Status == model.Todo && Ready(t) {
+			actionable = append(actionable, t)
+		}
+	}
+	return actionable
+}
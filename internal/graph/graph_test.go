@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"testing"
+
+	model "github.com/weakphish/yapper/internal/taskmodel"
+)
+
+func TestWouldCreateCycle(t *testing.T) {
+	a := &model.Task{ID: "a"}
+	b := &model.Task{ID: "b", DependsOn: []*model.Task{a}}
+	c := &model.Task{ID: "c", DependsOn: []*model.Task{b}}
+
+	if WouldCreateCycle(a, a) != true {
+		t.Error("a task depending on itself should be a cycle")
+	}
+	if got := WouldCreateCycle(a, c); got != true {
+		t.Errorf("a -> c would close a cycle through b, got %v", got)
+	}
+	if got := WouldCreateCycle(c, a); got != false {
+		t.Errorf("c -> a does not close a cycle, got %v", got)
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	a := &model.Task{ID: "a"}
+	b := &model.Task{ID: "b", DependsOn: []*model.Task{a}}
+	c := &model.Task{ID: "c", DependsOn: []*model.Task{b}}
+
+	sorted, err := TopoSort([]*model.Task{c, b, a})
+	if err != nil {
+		t.Fatalf("TopoSort: %v", err)
+	}
+
+	pos := make(map[string]int, len(sorted))
+	for i, task := range sorted {
+		pos[task.ID] = i
+	}
+	if pos["a"] > pos["b"] || pos["b"] > pos["c"] {
+		t.Fatalf("expected order a, b, c; got %v", []string{sorted[0].ID, sorted[1].ID, sorted[2].ID})
+	}
+}
+
+func TestTopoSort_Cycle(t *testing.T) {
+	a := &model.Task{ID: "a"}
+	b := &model.Task{ID: "b", DependsOn: []*model.Task{a}}
+	a.DependsOn = []*model.Task{b}
+
+	if _, err := TopoSort([]*model.Task{a, b}); err == nil {
+		t.Fatal("expected an error for a cyclic graph")
+	}
+}
+
+func TestReadyAndNextActionable(t *testing.T) {
+	done := &model.Task{ID: "done", Status: model.Completed}
+	blocked := &model.Task{ID: "blocked", Status: model.Todo, DependsOn: []*model.Task{{ID: "pending", Status: model.Todo}}}
+	ready := &model.Task{ID: "ready", Status: model.Todo, DependsOn: []*model.Task{done}}
+
+	if !Ready(ready) {
+		t.Error("ready task should be Ready")
+	}
+	if Ready(blocked) {
+		t.Error("blocked task should not be Ready")
+	}
+
+	actionable := NextActionable([]*model.Task{done, blocked, ready})
+	if len(actionable) != 1 || actionable[0].ID != "ready" {
+		t.Fatalf("NextActionable = %v, want only [ready]", actionable)
+	}
+}
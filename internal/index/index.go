@@ -2,6 +2,8 @@ package index
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/weakphish/yapper/internal/model"
@@ -14,6 +16,28 @@ type TaskFilter struct {
 	Statuses []model.TaskStatus
 	Tags     []string
 	NoteIDs  []model.NoteID
+	// ExcludeTags drops tasks carrying any of these tags. It composes with
+	// Tags (both are applied) but is ignored when TagExpr is set.
+	ExcludeTags []string
+	// TagExpr, when set, is parsed with ParseTagExpr and takes precedence
+	// over Tags/ExcludeTags, supporting negation, glob patterns, and
+	// boolean composition (see ParseTagExpr for the grammar).
+	TagExpr *string
+}
+
+// LogFilter allows callers to control the set of log entries returned by the
+// index. Filters are optional; omitting every field results in all known log
+// entries. It mirrors TaskFilter's tag-matching fields.
+type LogFilter struct {
+	NoteIDs []model.NoteID
+	Tags    []string
+	// ExcludeTags drops log entries carrying any of these tags. It composes
+	// with Tags (both are applied) but is ignored when TagExpr is set.
+	ExcludeTags []string
+	// TagExpr, when set, is parsed with ParseTagExpr and takes precedence
+	// over Tags/ExcludeTags, supporting negation, glob patterns, and
+	// boolean composition (see ParseTagExpr for the grammar).
+	TagExpr *string
 }
 
 // NoteFilter constrains note listing queries to a time range. The range is
@@ -21,6 +45,11 @@ type TaskFilter struct {
 type NoteFilter struct {
 	Start *time.Time
 	End   *time.Time
+	// Metadata constrains results to notes whose frontmatter contains every
+	// given key (normalized to lowercase, as zk does) with a matching
+	// string-equal value. A frontmatter value is compared using fmt.Sprint,
+	// so scalar YAML values (numbers, bools) still match their string form.
+	Metadata map[string]string
 }
 
 // TagItems describes every entity associated with a specific tag. Tags may
@@ -48,13 +77,58 @@ type IndexStore interface {
 	ListTasks(ctx context.Context, filter TaskFilter) ([]model.Task, error)
 	// GetLogEntriesForTask returns the log entries referencing a given task.
 	GetLogEntriesForTask(ctx context.Context, id model.TaskID) ([]model.LogEntry, error)
+	// ListLogEntries lists log entries that satisfy the provided filter.
+	ListLogEntries(ctx context.Context, filter LogFilter) ([]model.LogEntry, error)
 	// GetMentionsForTask returns the mentions pointing to a given task.
 	GetMentionsForTask(ctx context.Context, id model.TaskID) ([]model.TaskMention, error)
 	// ListNotes returns every note in the index ordered by descending date.
 	ListNotes(ctx context.Context, filter NoteFilter) ([]model.Note, error)
+	// ListNotesByMetadata is a convenience wrapper around ListNotes for the
+	// common case of filtering on a single frontmatter key, e.g.
+	// ListNotesByMetadata(ctx, "status", "draft").
+	ListNotesByMetadata(ctx context.Context, key, value string) ([]model.Note, error)
 	// ListTags returns the unique set of tags known to the index sorted
 	// lexicographically.
 	ListTags(ctx context.Context) ([]string, error)
 	// ItemsForTag returns every indexed entity linked to the provided tag.
 	ItemsForTag(ctx context.Context, tag string) (TagItems, bool, error)
+	// NeedsReindex reports whether the note at path has changed since the
+	// fingerprint last recorded via RecordFingerprint, so a caller walking
+	// Vault.ListNotePaths can skip parsing and upserting unchanged notes. A
+	// path with no recorded fingerprint always needs reindexing.
+	NeedsReindex(ctx context.Context, path string, fingerprint NoteFingerprint) (bool, error)
+	// RecordFingerprint persists fingerprint as the last-indexed state for its
+	// path, for a later NeedsReindex call to compare against.
+	RecordFingerprint(ctx context.Context, fingerprint NoteFingerprint) error
+	// ListUnlinkedMentions finds plain-text occurrences of target's title or
+	// any alias inside other notes' bodies that are not already a formal
+	// [[wikilink]] or [markdown](link) to it.
+	ListUnlinkedMentions(ctx context.Context, target model.NoteID) ([]model.UnlinkedMention, error)
+	// GetBacklinks returns every link that resolved to noteID, i.e. every
+	// note pointing at it via a `[[wiki link]]`.
+	GetBacklinks(ctx context.Context, noteID model.NoteID) ([]model.NoteLink, error)
+}
+
+// NoteFingerprint captures the on-disk state of a note at the time it was
+// last indexed, so a subsequent reindex can cheaply tell whether the file
+// actually changed rather than reparsing everything on every run.
+type NoteFingerprint struct {
+	Path    string    `json:"path"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"`
+}
+
+// fingerprintFromNote derives a NoteFingerprint from an already-loaded note.
+// Size and SHA256 are computed over the note's content rather than a fresh
+// stat, since Vault has already read the file by the time a note reaches the
+// index layer.
+func fingerprintFromNote(note *model.Note) NoteFingerprint {
+	sum := sha256.Sum256([]byte(note.Content))
+	return NoteFingerprint{
+		Path:    note.Path,
+		ModTime: note.Date,
+		Size:    int64(len(note.Content)),
+		SHA256:  hex.EncodeToString(sum[:]),
+	}
 }
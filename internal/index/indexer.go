@@ -0,0 +1,177 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+// batchUpserter is implemented by stores that can commit a batch of parsed
+// notes under a single lock acquisition (InMemoryIndexStore does). Indexer
+// prefers this when available and falls back to one UpsertParsedNote call
+// per note for stores that don't implement it.
+type batchUpserter interface {
+	UpsertParsedNotes(ctx context.Context, batch []*parser.ParsedNote) error
+}
+
+// IndexerConfig controls Indexer's concurrency and batching behavior.
+type IndexerConfig struct {
+	// Workers is the number of goroutines that parse notes concurrently.
+	// Defaults to 4 if zero or negative.
+	Workers int
+	// BatchSize is the number of parsed notes the writer goroutine
+	// accumulates before committing them to the store. Defaults to 32 if
+	// zero or negative.
+	BatchSize int
+}
+
+// withDefaults returns cfg with zero-value fields replaced by their defaults.
+func (cfg IndexerConfig) withDefaults() IndexerConfig {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 32
+	}
+	return cfg
+}
+
+// Indexer performs a full reindex of a vault with bounded memory use. A
+// single goroutine streams notes from the vault via Vault.WalkNotes (so the
+// whole vault's content is never materialized at once), cfg.Workers goroutines
+// parse those notes concurrently, and one writer goroutine commits the parsed
+// results to the store in batches of cfg.BatchSize — keeping the store's
+// lock hold time proportional to a batch rather than the whole vault, while
+// still letting parsing use every core.
+type Indexer struct {
+	vault  vault.Vault
+	parser parser.NoteParser
+	store  IndexStore
+	cfg    IndexerConfig
+}
+
+// NewIndexer wires together the collaborating components. Each dependency
+// must be non-nil; zero-valued cfg fields fall back to sane defaults.
+func NewIndexer(v vault.Vault, p parser.NoteParser, store IndexStore, cfg IndexerConfig) (*Indexer, error) {
+	if v == nil || p == nil || store == nil {
+		return nil, errors.New("vault, parser, and store are required")
+	}
+	return &Indexer{vault: v, parser: p, store: store, cfg: cfg.withDefaults()}, nil
+}
+
+// Run streams, parses, and indexes every note in the vault, returning the
+// first error encountered by any stage. Once an error occurs, in-flight
+// stages are canceled and drained so Run doesn't leak goroutines.
+func (ix *Indexer) Run(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	notes := make(chan *model.Note, ix.cfg.Workers)
+	parsed := make(chan *parser.ParsedNote, ix.cfg.Workers)
+
+	var walkWG sync.WaitGroup
+	walkWG.Add(1)
+	go func() {
+		defer walkWG.Done()
+		defer close(notes)
+		err := ix.vault.WalkNotes(runCtx, func(n *model.Note) error {
+			select {
+			case notes <- n:
+				return nil
+			case <-runCtx.Done():
+				return runCtx.Err()
+			}
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fail(fmt.Errorf("walk vault: %w", err))
+		}
+	}()
+
+	var workerWG sync.WaitGroup
+	for i := 0; i < ix.cfg.Workers; i++ {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			for note := range notes {
+				result, err := ix.parser.Parse(runCtx, note)
+				if err != nil {
+					fail(fmt.Errorf("parse note %q: %w", note.Path, err))
+					return
+				}
+				select {
+				case parsed <- result:
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		workerWG.Wait()
+		close(parsed)
+	}()
+
+	var writerWG sync.WaitGroup
+	writerWG.Add(1)
+	go func() {
+		defer writerWG.Done()
+		batch := make([]*parser.ParsedNote, 0, ix.cfg.BatchSize)
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			if err := ix.commitBatch(runCtx, batch); err != nil {
+				fail(fmt.Errorf("commit batch: %w", err))
+			}
+			batch = batch[:0]
+		}
+		for result := range parsed {
+			batch = append(batch, result)
+			if len(batch) >= ix.cfg.BatchSize {
+				flush()
+			}
+		}
+		flush()
+	}()
+
+	walkWG.Wait()
+	workerWG.Wait()
+	writerWG.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// commitBatch writes a batch of parsed notes to the store, preferring a
+// single-lock batch upsert when the store supports it.
+func (ix *Indexer) commitBatch(ctx context.Context, batch []*parser.ParsedNote) error {
+	if bu, ok := ix.store.(batchUpserter); ok {
+		return bu.UpsertParsedNotes(ctx, batch)
+	}
+	for _, result := range batch {
+		if err := ix.store.UpsertParsedNote(ctx, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+func TestIndexer_Run(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a.md": "# Note A\n\n## Tasks\n\n- [ ] Task from A [T-1]\n",
+		"b.md": "# Note B\n\n## Tasks\n\n- [ ] Task from B [T-2]\n",
+		"c.md": "# Note C\n\n## Tasks\n\n- [ ] Task from C [T-3]\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	v, err := vault.NewNamedFileSystemVault("test", root)
+	if err != nil {
+		t.Fatalf("NewNamedFileSystemVault: %v", err)
+	}
+	store := NewInMemoryIndexStore()
+	ix, err := NewIndexer(v, parser.NewRegexNoteParser(), store, IndexerConfig{Workers: 2, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("NewIndexer() error = %v", err)
+	}
+
+	if err := ix.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	tasks, err := store.ListTasks(context.Background(), TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d: %+v", len(tasks), tasks)
+	}
+
+	notes, err := store.ListNotes(context.Background(), NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if len(notes) != 3 {
+		t.Fatalf("expected 3 notes, got %d", len(notes))
+	}
+}
+
+func TestIndexer_Run_PropagatesParseErrors(t *testing.T) {
+	v := &fakeVault{
+		paths: []string{"note.md"},
+		notes: map[string]*model.Note{
+			"note.md": {ID: "n", Path: "note.md", Title: "N", Date: time.Now().UTC()},
+		},
+	}
+	ix, err := NewIndexer(v, &fakeParser{err: errors.New("parse boom")}, NewInMemoryIndexStore(), IndexerConfig{})
+	if err != nil {
+		t.Fatalf("NewIndexer() error = %v", err)
+	}
+	if err := ix.Run(context.Background()); err == nil {
+		t.Fatalf("Run() expected error, got nil")
+	}
+}
@@ -23,6 +23,18 @@ type InMemoryIndexStore struct {
 	mentions map[model.TaskID][]model.TaskMention
 	tagIndex map[string]*tagBucket
 	noteData map[model.NoteID]*noteSnapshot
+
+	linksByNote     map[model.NoteID][]model.NoteLink
+	backlinksByNote map[model.NoteID][]model.NoteLink
+
+	fingerprints map[string]NoteFingerprint
+
+	// mentionMatcher caches the Aho-Corasick automaton built from every
+	// note's title and aliases. It's invalidated (set to nil) whenever the
+	// note set changes and rebuilt lazily on the next ListUnlinkedMentions
+	// call, so repeated full-vault scans stay linear in total content
+	// length rather than rebuilding per call.
+	mentionMatcher *mentionMatcher
 }
 
 type tagBucket struct {
@@ -43,15 +55,96 @@ type noteSnapshot struct {
 // NewInMemoryIndexStore constructs a ready-to-use in-memory store instance.
 func NewInMemoryIndexStore() *InMemoryIndexStore {
 	return &InMemoryIndexStore{
-		notes:    make(map[model.NoteID]*model.Note),
-		tasks:    make(map[model.TaskID]model.Task),
-		logs:     make(map[model.LogEntryID]model.LogEntry),
-		mentions: make(map[model.TaskID][]model.TaskMention),
-		tagIndex: make(map[string]*tagBucket),
-		noteData: make(map[model.NoteID]*noteSnapshot),
+		notes:           make(map[model.NoteID]*model.Note),
+		tasks:           make(map[model.TaskID]model.Task),
+		logs:            make(map[model.LogEntryID]model.LogEntry),
+		mentions:        make(map[model.TaskID][]model.TaskMention),
+		tagIndex:        make(map[string]*tagBucket),
+		noteData:        make(map[model.NoteID]*noteSnapshot),
+		linksByNote:     make(map[model.NoteID][]model.NoteLink),
+		backlinksByNote: make(map[model.NoteID][]model.NoteLink),
+		fingerprints:    make(map[string]NoteFingerprint),
 	}
 }
 
+// ListUnlinkedMentions finds plain-text occurrences of target's title or any
+// alias inside other notes' bodies that aren't already a [[wikilink]] or
+// [markdown](link) to it. The underlying matcher is cached and rebuilt
+// lazily (see mentionMatcher), so repeated calls stay cheap between note
+// upserts/removals.
+func (s *InMemoryIndexStore) ListUnlinkedMentions(ctx context.Context, target model.NoteID) ([]model.UnlinkedMention, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.mentionMatcher == nil {
+		s.mentionMatcher = buildMentionMatcher(s.notes)
+	}
+	matcher := s.mentionMatcher
+	_, targetExists := s.notes[target]
+	notes := make(map[model.NoteID]*model.Note, len(s.notes))
+	for id, note := range s.notes {
+		notes[id] = note
+	}
+	s.mu.Unlock()
+
+	if !targetExists {
+		return nil, nil
+	}
+
+	var mentions []model.UnlinkedMention
+	for sourceID, note := range notes {
+		if sourceID == target {
+			continue
+		}
+		for _, c := range collectMentionCandidates(matcher, target, note.Content) {
+			line, excerpt := lineAndExcerptAt(note.Content, c.start, c.end)
+			mentions = append(mentions, model.UnlinkedMention{
+				SourceNoteID: sourceID,
+				TargetNoteID: target,
+				Line:         line,
+				Excerpt:      excerpt,
+				MatchedAlias: c.target.text,
+			})
+		}
+	}
+
+	sort.Slice(mentions, func(i, j int) bool {
+		if mentions[i].SourceNoteID != mentions[j].SourceNoteID {
+			return mentions[i].SourceNoteID < mentions[j].SourceNoteID
+		}
+		return mentions[i].Line < mentions[j].Line
+	})
+	return mentions, nil
+}
+
+// NeedsReindex reports whether path's last recorded fingerprint differs from
+// fingerprint (or none was recorded yet).
+func (s *InMemoryIndexStore) NeedsReindex(ctx context.Context, path string, fingerprint NoteFingerprint) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	existing, ok := s.fingerprints[path]
+	if !ok {
+		return true, nil
+	}
+	return existing != fingerprint, nil
+}
+
+// RecordFingerprint stores fingerprint as path's last-indexed state.
+func (s *InMemoryIndexStore) RecordFingerprint(ctx context.Context, fingerprint NoteFingerprint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fingerprints[fingerprint.Path] = fingerprint
+	return nil
+}
+
 // UpsertParsedNote replaces any previously indexed state for parsed.Note.Note.
 func (s *InMemoryIndexStore) UpsertParsedNote(ctx context.Context, parsed *parser.ParsedNote) error {
 	if parsed == nil || parsed.Note == nil {
@@ -63,7 +156,35 @@ func (s *InMemoryIndexStore) UpsertParsedNote(ctx context.Context, parsed *parse
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.upsertParsedNoteLocked(parsed)
+	return nil
+}
+
+// UpsertParsedNotes commits an entire batch of parsed notes under a single
+// lock acquisition instead of one Lock/Unlock cycle per note. Indexer relies
+// on this to keep its writer goroutine's lock hold times proportional to a
+// batch rather than the whole vault.
+func (s *InMemoryIndexStore) UpsertParsedNotes(ctx context.Context, batch []*parser.ParsedNote) error {
+	for _, parsed := range batch {
+		if parsed == nil || parsed.Note == nil {
+			return errors.New("parsed note cannot be nil")
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, parsed := range batch {
+		s.upsertParsedNoteLocked(parsed)
+	}
+	return nil
+}
 
+// upsertParsedNoteLocked performs the actual upsert. The caller must hold
+// s.mu for writing.
+func (s *InMemoryIndexStore) upsertParsedNoteLocked(parsed *parser.ParsedNote) {
 	s.removeNoteLocked(parsed.Note.ID)
 
 	s.notes[parsed.Note.ID] = parsed.Note
@@ -108,7 +229,19 @@ func (s *InMemoryIndexStore) UpsertParsedNote(ctx context.Context, parsed *parse
 
 	s.noteData[parsed.Note.ID] = snap
 
-	return nil
+	resolvedLinks := make([]model.NoteLink, 0, len(parsed.Links))
+	for _, link := range parsed.Links {
+		resolved := link
+		if target, ok := s.resolveLinkLocked(link.TargetText); ok {
+			id := target.ID
+			resolved.TargetNoteID = &id
+			s.backlinksByNote[id] = append(s.backlinksByNote[id], resolved)
+		}
+		resolvedLinks = append(resolvedLinks, resolved)
+	}
+	s.linksByNote[parsed.Note.ID] = resolvedLinks
+
+	s.mentionMatcher = nil
 }
 
 // RemoveNote drops every indexed entity derived from noteID.
@@ -121,6 +254,7 @@ func (s *InMemoryIndexStore) RemoveNote(ctx context.Context, noteID model.NoteID
 	s.removeNoteLocked(noteID)
 	delete(s.notes, noteID)
 	delete(s.noteData, noteID)
+	s.mentionMatcher = nil
 	return nil
 }
 
@@ -179,6 +313,36 @@ func (s *InMemoryIndexStore) removeNoteLocked(noteID model.NoteID) {
 		}
 		s.cleanupTag(tag)
 	}
+
+	// Only noteID's own outgoing links are retired here; other notes' links
+	// that already resolved to noteID are left alone and become dangling
+	// until those source notes are themselves reindexed (e.g. on the next
+	// FullReindex), matching the mutation-is-local-to-the-note-being-touched
+	// contract the rest of this store follows.
+	for _, link := range s.linksByNote[noteID] {
+		if link.TargetNoteID != nil {
+			s.removeBacklinkLocked(*link.TargetNoteID, link)
+		}
+	}
+	delete(s.linksByNote, noteID)
+}
+
+// removeBacklinkLocked drops a single link from target's backlink list. The
+// caller must hold s.mu.
+func (s *InMemoryIndexStore) removeBacklinkLocked(target model.NoteID, link model.NoteLink) {
+	backlinks := s.backlinksByNote[target]
+	filtered := backlinks[:0]
+	for _, existing := range backlinks {
+		if existing.SourceNoteID == link.SourceNoteID && existing.SourceLine == link.SourceLine && existing.TargetText == link.TargetText {
+			continue
+		}
+		filtered = append(filtered, existing)
+	}
+	if len(filtered) == 0 {
+		delete(s.backlinksByNote, target)
+	} else {
+		s.backlinksByNote[target] = filtered
+	}
 }
 
 // GetTask returns a task if it exists.
@@ -197,12 +361,22 @@ func (s *InMemoryIndexStore) ListTasks(ctx context.Context, filter TaskFilter) (
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+
+	var expr TagExpr
+	if filter.TagExpr != nil {
+		parsed, err := ParseTagExpr(*filter.TagExpr)
+		if err != nil {
+			return nil, err
+		}
+		expr = parsed
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var result []model.Task
 	for _, task := range s.tasks {
-		if !matchTaskFilter(task, filter) {
+		if !matchTaskFilter(task, filter, expr) {
 			continue
 		}
 		result = append(result, task)
@@ -237,6 +411,39 @@ func (s *InMemoryIndexStore) GetLogEntriesForTask(ctx context.Context, id model.
 	return entries, nil
 }
 
+// ListLogEntries returns the log entries that satisfy the provided filter.
+func (s *InMemoryIndexStore) ListLogEntries(ctx context.Context, filter LogFilter) ([]model.LogEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var expr TagExpr
+	if filter.TagExpr != nil {
+		parsed, err := ParseTagExpr(*filter.TagExpr)
+		if err != nil {
+			return nil, err
+		}
+		expr = parsed
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []model.LogEntry
+	for _, entry := range s.logs {
+		if !matchLogFilter(entry, filter, expr) {
+			continue
+		}
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
 // GetMentionsForTask returns mentions associated with the task.
 func (s *InMemoryIndexStore) GetMentionsForTask(ctx context.Context, id model.TaskID) ([]model.TaskMention, error) {
 	if err := ctx.Err(); err != nil {
@@ -274,6 +481,86 @@ func (s *InMemoryIndexStore) ListNotes(ctx context.Context, filter NoteFilter) (
 	return notes, nil
 }
 
+// ListNotesByMetadata implements IndexStore.
+func (s *InMemoryIndexStore) ListNotesByMetadata(ctx context.Context, key, value string) ([]model.Note, error) {
+	return s.ListNotes(ctx, NoteFilter{Metadata: map[string]string{key: value}})
+}
+
+// GetBacklinks returns every link that resolved to noteID, i.e. every note
+// pointing at it via a `[[wiki link]]`.
+func (s *InMemoryIndexStore) GetBacklinks(ctx context.Context, noteID model.NoteID) ([]model.NoteLink, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	backlinks := append([]model.NoteLink(nil), s.backlinksByNote[noteID]...)
+	sort.Slice(backlinks, func(i, j int) bool {
+		if backlinks[i].SourceNoteID == backlinks[j].SourceNoteID {
+			return backlinks[i].SourceLine < backlinks[j].SourceLine
+		}
+		return backlinks[i].SourceNoteID < backlinks[j].SourceNoteID
+	})
+	return backlinks, nil
+}
+
+// ResolveLink resolves wiki-link text against the vault's notes, trying (in
+// order) an exact NoteID/path match, a case-insensitive path match, an exact
+// title match, a case-insensitive title match, and finally a fuzzy title
+// match — the same fallback order zk uses for wiki-link resolution. It
+// returns false if text doesn't resolve to any note.
+func (s *InMemoryIndexStore) ResolveLink(text string) (*model.Note, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resolveLinkLocked(text)
+}
+
+// resolveLinkLocked implements ResolveLink's tiered matching. The caller must
+// hold s.mu (for reading or writing).
+func (s *InMemoryIndexStore) resolveLinkLocked(text string) (*model.Note, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, false
+	}
+
+	tiers := []func(*model.Note) bool{
+		func(n *model.Note) bool { return string(n.ID) == text || n.Path == text },
+		func(n *model.Note) bool { return strings.EqualFold(n.Path, text) },
+		func(n *model.Note) bool { return n.Title == text },
+		func(n *model.Note) bool { return strings.EqualFold(n.Title, text) },
+		func(n *model.Note) bool { return fuzzyTitleKey(n.Title) == fuzzyTitleKey(text) },
+	}
+
+	for _, matches := range tiers {
+		var best *model.Note
+		for _, note := range s.notes {
+			if !matches(note) {
+				continue
+			}
+			if best == nil || note.ID < best.ID {
+				best = note
+			}
+		}
+		if best != nil {
+			return best, true
+		}
+	}
+	return nil, false
+}
+
+// fuzzyTitleKey normalizes a title for loose comparison: lowercased with
+// every non-alphanumeric character dropped, so "Project: Yapper!" and
+// "project yapper" compare equal.
+func fuzzyTitleKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 // ListTags returns the sorted set of tags.
 func (s *InMemoryIndexStore) ListTags(ctx context.Context) ([]string, error) {
 	if err := ctx.Err(); err != nil {
@@ -289,7 +576,10 @@ func (s *InMemoryIndexStore) ListTags(ctx context.Context) ([]string, error) {
 	return tags, nil
 }
 
-// ItemsForTag returns every entity referencing the provided tag.
+// ItemsForTag returns every entity referencing the provided tag. tag may be
+// an exact (leaf) tag or a hierarchical prefix (e.g. "project" matches both
+// "project" and "project/yapper"); results from every matching tag are
+// unioned together.
 func (s *InMemoryIndexStore) ItemsForTag(ctx context.Context, tag string) (TagItems, bool, error) {
 	if err := ctx.Err(); err != nil {
 		return TagItems{}, false, err
@@ -300,23 +590,42 @@ func (s *InMemoryIndexStore) ItemsForTag(ctx context.Context, tag string) (TagIt
 	}
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	bucket, ok := s.tagIndex[tag]
-	if !ok {
+
+	matchedTasks := make(map[model.TaskID]struct{})
+	matchedLogs := make(map[model.LogEntryID]struct{})
+	matchedMentions := make(map[string]model.TaskMention)
+	found := false
+	for candidate, bucket := range s.tagIndex {
+		if candidate != tag && !strings.HasPrefix(candidate, tag+"/") {
+			continue
+		}
+		found = true
+		for id := range bucket.taskIDs {
+			matchedTasks[id] = struct{}{}
+		}
+		for id := range bucket.logIDs {
+			matchedLogs[id] = struct{}{}
+		}
+		for key, mention := range bucket.mentions {
+			matchedMentions[key] = mention
+		}
+	}
+	if !found {
 		return TagItems{}, false, nil
 	}
 
 	items := TagItems{Tag: tag}
-	for id := range bucket.taskIDs {
+	for id := range matchedTasks {
 		if task, ok := s.tasks[id]; ok {
 			items.Tasks = append(items.Tasks, task)
 		}
 	}
-	for id := range bucket.logIDs {
+	for id := range matchedLogs {
 		if entry, ok := s.logs[id]; ok {
 			items.LogEntries = append(items.LogEntries, entry)
 		}
 	}
-	for _, mention := range bucket.mentions {
+	for _, mention := range matchedMentions {
 		items.Mentions = append(items.Mentions, mention)
 	}
 
@@ -382,14 +691,36 @@ func (s *InMemoryIndexStore) cleanupTag(tag string) {
 	}
 }
 
-func matchTaskFilter(task model.Task, filter TaskFilter) bool {
+func matchTaskFilter(task model.Task, filter TaskFilter, expr TagExpr) bool {
 	if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, task.Status) {
 		return false
 	}
+	if len(filter.NoteIDs) > 0 && !containsNoteID(filter.NoteIDs, task.NoteID) {
+		return false
+	}
+	if expr != nil {
+		return expr.Eval(normalizeTags(task.Tags))
+	}
 	if len(filter.Tags) > 0 && !hasAnyTag(task.Tags, filter.Tags) {
 		return false
 	}
-	if len(filter.NoteIDs) > 0 && !containsNoteID(filter.NoteIDs, task.NoteID) {
+	if len(filter.ExcludeTags) > 0 && hasAnyTag(task.Tags, filter.ExcludeTags) {
+		return false
+	}
+	return true
+}
+
+func matchLogFilter(entry model.LogEntry, filter LogFilter, expr TagExpr) bool {
+	if len(filter.NoteIDs) > 0 && !containsNoteID(filter.NoteIDs, entry.NoteID) {
+		return false
+	}
+	if expr != nil {
+		return expr.Eval(normalizeTags(entry.Tags))
+	}
+	if len(filter.Tags) > 0 && !hasAnyTag(entry.Tags, filter.Tags) {
+		return false
+	}
+	if len(filter.ExcludeTags) > 0 && hasAnyTag(entry.Tags, filter.ExcludeTags) {
 		return false
 	}
 	return true
@@ -443,29 +774,74 @@ func matchNoteFilter(note *model.Note, filter NoteFilter) bool {
 	if filter.End != nil && note.Date.After(*filter.End) {
 		return false
 	}
+	for key, want := range filter.Metadata {
+		got, ok := note.Frontmatter[strings.ToLower(key)]
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
 	return true
 }
 
+// normalizeTags is the entry point for turning the raw tag strings a parser
+// extracted (bare "#hashtag", Bear-style "#multi word tags#", colon paths
+// like "project:yapper") into the flat, deduplicated, sorted set of tags
+// actually stored in tagBucket. Each raw tag may expand into more than one
+// stored tag (see expandTag), so this flattens across all of them.
 func normalizeTags(tags []string) []string {
 	var result []string
 	seen := make(map[string]struct{})
 	for _, tag := range tags {
-		norm := normalizeTag(tag)
-		if norm == "" {
-			continue
-		}
-		if _, ok := seen[norm]; ok {
-			continue
+		for _, norm := range expandTag(tag) {
+			if _, ok := seen[norm]; ok {
+				continue
+			}
+			seen[norm] = struct{}{}
+			result = append(result, norm)
 		}
-		seen[norm] = struct{}{}
-		result = append(result, norm)
 	}
 	sort.Strings(result)
 	return result
 }
 
+// normalizeTag normalizes a single tag (or tag query) to its canonical
+// form: lowercased, trimmed, and with internal whitespace collapsed to
+// hyphens so Bear-style "multi word tags" lines up with its stored form
+// "multi-word-tags". It does not expand colon paths; use expandTag when a
+// raw tag may need to become several stored tags.
 func normalizeTag(tag string) string {
-	return strings.ToLower(strings.TrimSpace(tag))
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return strings.Join(strings.Fields(tag), "-")
+}
+
+// expandTag normalizes a single raw tag and, if it's a colon-delimited
+// hierarchical path (e.g. "project:yapper"), expands it into every
+// hierarchical prefix plus the full leaf, joined with "/" — so
+// "project:yapper" is stored as both "project" and "project/yapper". Tags
+// without a colon normalize to a single entry.
+func expandTag(tag string) []string {
+	norm := normalizeTag(tag)
+	if norm == "" {
+		return nil
+	}
+	if !strings.Contains(norm, ":") {
+		return []string{norm}
+	}
+
+	var out []string
+	var path string
+	for _, seg := range strings.Split(norm, ":") {
+		if seg == "" {
+			continue
+		}
+		if path == "" {
+			path = seg
+		} else {
+			path += "/" + seg
+		}
+		out = append(out, path)
+	}
+	return out
 }
 
 func mentionKey(m model.TaskMention) string {
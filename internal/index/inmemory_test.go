@@ -72,6 +72,23 @@ func TestInMemoryIndexStoreUpsertQueryAndRemove(t *testing.T) {
 		t.Fatalf("ListTasks() = %+v, want task %s", tasks, task.ID)
 	}
 
+	exclWork := "-work"
+	tasks, err = store.ListTasks(ctx, TaskFilter{TagExpr: &exclWork})
+	if err != nil {
+		t.Fatalf("ListTasks(TagExpr=-work) error = %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("ListTasks(TagExpr=-work) = %+v, want none (task is tagged work)", tasks)
+	}
+
+	tasks, err = store.ListTasks(ctx, TaskFilter{ExcludeTags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("ListTasks(ExcludeTags=[work]) error = %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("ListTasks(ExcludeTags=[work]) = %+v, want none (task is tagged work)", tasks)
+	}
+
 	notes, err := store.ListNotes(ctx, NoteFilter{})
 	if err != nil {
 		t.Fatalf("ListNotes() error = %v", err)
@@ -89,6 +106,23 @@ func TestInMemoryIndexStoreUpsertQueryAndRemove(t *testing.T) {
 		t.Fatalf("GetMentionsForTask() error = %v len=%d", err, len(mentions))
 	}
 
+	logs, err := store.ListLogEntries(ctx, LogFilter{Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("ListLogEntries(Tags=[work]) error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ID != logEntry.ID {
+		t.Fatalf("ListLogEntries(Tags=[work]) = %+v, want log %s", logs, logEntry.ID)
+	}
+
+	exclWorkLogs := "-work"
+	logs, err = store.ListLogEntries(ctx, LogFilter{TagExpr: &exclWorkLogs})
+	if err != nil {
+		t.Fatalf("ListLogEntries(TagExpr=-work) error = %v", err)
+	}
+	if len(logs) != 0 {
+		t.Fatalf("ListLogEntries(TagExpr=-work) = %+v, want none (entry is tagged work)", logs)
+	}
+
 	tags, err := store.ListTags(ctx)
 	if err != nil {
 		t.Fatalf("ListTags() error = %v", err)
@@ -130,3 +164,245 @@ func TestInMemoryIndexStoreUpsertQueryAndRemove(t *testing.T) {
 		t.Fatalf("ItemsForTag() after remove returned ok=true, want false")
 	}
 }
+
+// TestInMemoryIndexStoreItemsForTagPrefixMatch validates that querying a
+// hierarchical tag prefix (e.g. "project") returns entities tagged with that
+// prefix's full leaf form (e.g. "project/yapper"), unioned with any exact
+// match on the prefix itself.
+func TestInMemoryIndexStoreItemsForTagPrefixMatch(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	note := &model.Note{ID: "note-1", Path: "note-1.md", Title: "Note 1", Date: time.Now().UTC()}
+	task := model.Task{
+		ID:        "task-1",
+		NoteID:    note.ID,
+		Title:     "Ship yapper",
+		Status:    model.TaskStatusTodo,
+		Tags:      []string{"project/yapper"},
+		CreatedAt: time.Now().UTC(),
+		Line:      1,
+	}
+	parsed := &parser.ParsedNote{Note: note, Tasks: []model.Task{task}}
+
+	if err := store.UpsertParsedNote(ctx, parsed); err != nil {
+		t.Fatalf("UpsertParsedNote() error = %v", err)
+	}
+
+	items, ok, err := store.ItemsForTag(ctx, "project")
+	if err != nil || !ok {
+		t.Fatalf("ItemsForTag(project) error = %v ok=%v", err, ok)
+	}
+	if len(items.Tasks) != 1 || items.Tasks[0].ID != task.ID {
+		t.Fatalf("ItemsForTag(project) = %+v, want task %s", items, task.ID)
+	}
+
+	items, ok, err = store.ItemsForTag(ctx, "project/yapper")
+	if err != nil || !ok {
+		t.Fatalf("ItemsForTag(project/yapper) error = %v ok=%v", err, ok)
+	}
+	if len(items.Tasks) != 1 || items.Tasks[0].ID != task.ID {
+		t.Fatalf("ItemsForTag(project/yapper) = %+v, want task %s", items, task.ID)
+	}
+
+	if _, ok, err := store.ItemsForTag(ctx, "unrelated"); err != nil || ok {
+		t.Fatalf("ItemsForTag(unrelated) = ok=%v err=%v, want ok=false", ok, err)
+	}
+}
+
+// TestInMemoryIndexStoreLinksAndBacklinks validates that [[wiki links]] are
+// resolved against the vault's notes, that backlinks are tracked in reverse,
+// and that both directions are retired together when the source note is
+// removed.
+func TestInMemoryIndexStoreLinksAndBacklinks(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{ID: "notes/daily.md", Path: "notes/daily.md", Title: "Daily", Date: time.Now().UTC()}
+	link := model.NoteLink{SourceNoteID: source.ID, SourceLine: 3, TargetText: "project overview"}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source, Links: []model.NoteLink{link}}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	resolved, ok := store.ResolveLink("project overview")
+	if !ok || resolved.ID != target.ID {
+		t.Fatalf("ResolveLink() = %+v, ok=%v, want %s", resolved, ok, target.ID)
+	}
+
+	backlinks, err := store.GetBacklinks(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetBacklinks() error = %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].SourceNoteID != source.ID || backlinks[0].TargetNoteID == nil || *backlinks[0].TargetNoteID != target.ID {
+		t.Fatalf("GetBacklinks() = %+v, want a resolved link from %s", backlinks, source.ID)
+	}
+
+	if err := store.RemoveNote(ctx, source.ID); err != nil {
+		t.Fatalf("RemoveNote(source) error = %v", err)
+	}
+	backlinks, err = store.GetBacklinks(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetBacklinks() after remove error = %v", err)
+	}
+	if len(backlinks) != 0 {
+		t.Fatalf("GetBacklinks() after remove = %+v, want empty", backlinks)
+	}
+
+	if _, ok := store.ResolveLink("does not exist"); ok {
+		t.Fatalf("ResolveLink() unexpectedly resolved a nonexistent title")
+	}
+}
+
+func TestInMemoryIndexStoreNeedsReindex(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	fp := NoteFingerprint{Path: "a.md", ModTime: time.Now().UTC(), Size: 10, SHA256: "abc"}
+
+	needs, err := store.NeedsReindex(ctx, fp.Path, fp)
+	if err != nil {
+		t.Fatalf("NeedsReindex() error = %v", err)
+	}
+	if !needs {
+		t.Fatal("NeedsReindex() = false for a path with no recorded fingerprint, want true")
+	}
+
+	if err := store.RecordFingerprint(ctx, fp); err != nil {
+		t.Fatalf("RecordFingerprint() error = %v", err)
+	}
+	if needs, err := store.NeedsReindex(ctx, fp.Path, fp); err != nil || needs {
+		t.Fatalf("NeedsReindex() = (%v, %v) for an unchanged fingerprint, want (false, nil)", needs, err)
+	}
+
+	changed := fp
+	changed.Size = 11
+	if needs, err := store.NeedsReindex(ctx, changed.Path, changed); err != nil || !needs {
+		t.Fatalf("NeedsReindex() = (%v, %v) for a changed fingerprint, want (true, nil)", needs, err)
+	}
+}
+
+func TestInMemoryIndexStoreListUnlinkedMentions(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Aliases: []string{"Overview"}, Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{
+		ID:    "notes/daily.md",
+		Path:  "notes/daily.md",
+		Title: "Daily",
+		Content: "Talked about Project Overview today.\n" +
+			"Already linked via [[Project Overview]], so that one shouldn't count twice.\n" +
+			"A code block shouldn't count either: ```Overview```\n" +
+			"categoryOverview should not match as a substring.",
+		Date: time.Now().UTC(),
+	}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	mentions, err := store.ListUnlinkedMentions(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("ListUnlinkedMentions() error = %v", err)
+	}
+	if len(mentions) != 1 {
+		t.Fatalf("ListUnlinkedMentions() = %+v, want exactly one unlinked mention", mentions)
+	}
+	if mentions[0].SourceNoteID != source.ID || mentions[0].Line != 1 || mentions[0].MatchedAlias != "Project Overview" {
+		t.Fatalf("ListUnlinkedMentions()[0] = %+v, want source %s line 1 matching %q", mentions[0], source.ID, "Project Overview")
+	}
+
+	if mentions, err := store.ListUnlinkedMentions(ctx, "does not exist"); err != nil || mentions != nil {
+		t.Fatalf("ListUnlinkedMentions() for unknown target = (%+v, %v), want (nil, nil)", mentions, err)
+	}
+}
+
+// TestInMemoryIndexStoreListUnlinkedMentionsPrefixAlias covers an alias that
+// is a *prefix* of another alias/title rather than a suffix: the two
+// matches share a start position but different end positions, so dedup
+// logic keyed only on end position would let both through.
+func TestInMemoryIndexStoreListUnlinkedMentionsPrefixAlias(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Aliases: []string{"Project"}, Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{
+		ID:      "notes/daily.md",
+		Path:    "notes/daily.md",
+		Title:   "Daily",
+		Content: "Talked about Project Overview today.\n",
+		Date:    time.Now().UTC(),
+	}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	mentions, err := store.ListUnlinkedMentions(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("ListUnlinkedMentions() error = %v", err)
+	}
+	if len(mentions) != 1 {
+		t.Fatalf("ListUnlinkedMentions() = %+v, want exactly one unlinked mention", mentions)
+	}
+	if mentions[0].MatchedAlias != "Project Overview" {
+		t.Fatalf("ListUnlinkedMentions()[0].MatchedAlias = %q, want the longer title to win over its own prefix", mentions[0].MatchedAlias)
+	}
+}
+
+func TestInMemoryIndexStoreListNotesMetadataFilter(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	high := &model.Note{ID: "a.md", Path: "a.md", Title: "A", Date: time.Now().UTC(), Frontmatter: map[string]any{"priority": "high"}}
+	low := &model.Note{ID: "b.md", Path: "b.md", Title: "B", Date: time.Now().UTC(), Frontmatter: map[string]any{"priority": "low"}}
+	for _, n := range []*model.Note{high, low} {
+		if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: n}); err != nil {
+			t.Fatalf("UpsertParsedNote(%s) error = %v", n.ID, err)
+		}
+	}
+
+	notes, err := store.ListNotes(ctx, NoteFilter{Metadata: map[string]string{"Priority": "high"}})
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != high.ID {
+		t.Fatalf("ListNotes() = %+v, want only %s", notes, high.ID)
+	}
+
+	if notes, err := store.ListNotes(ctx, NoteFilter{Metadata: map[string]string{"priority": "nonexistent"}}); err != nil || len(notes) != 0 {
+		t.Fatalf("ListNotes() = (%+v, %v), want (empty, nil)", notes, err)
+	}
+}
+
+func TestInMemoryIndexStoreListNotesByMetadata(t *testing.T) {
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	draft := &model.Note{ID: "a.md", Path: "a.md", Title: "A", Date: time.Now().UTC(), Frontmatter: map[string]any{"status": "draft"}}
+	final := &model.Note{ID: "b.md", Path: "b.md", Title: "B", Date: time.Now().UTC(), Frontmatter: map[string]any{"status": "final"}}
+	for _, n := range []*model.Note{draft, final} {
+		if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: n}); err != nil {
+			t.Fatalf("UpsertParsedNote(%s) error = %v", n.ID, err)
+		}
+	}
+
+	notes, err := store.ListNotesByMetadata(ctx, "status", "draft")
+	if err != nil {
+		t.Fatalf("ListNotesByMetadata() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != draft.ID {
+		t.Fatalf("ListNotesByMetadata() = %+v, want only %s", notes, draft.ID)
+	}
+}
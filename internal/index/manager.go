@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 
+	"github.com/weakphish/yapper/internal/model"
 	"github.com/weakphish/yapper/internal/parser"
 	"github.com/weakphish/yapper/internal/vault"
 )
@@ -44,7 +46,7 @@ func (m *VaultIndexManager) FullReindex(ctx context.Context) error {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		if err := m.indexSingle(ctx, path); err != nil {
+		if err := m.indexIfChanged(ctx, path); err != nil {
 			return err
 		}
 	}
@@ -59,17 +61,66 @@ func (m *VaultIndexManager) ReindexNote(ctx context.Context, path string) error
 	return m.indexSingle(ctx, path)
 }
 
+// RemoveNote drops the indexed data for the note at the given vault-relative
+// path. It derives the NoteID the same way FileSystemVault.LoadNote does (the
+// slash-relative path) rather than loading the file, since by the time a
+// caller knows a note should be removed the file itself may already be gone.
+func (m *VaultIndexManager) RemoveNote(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	id := model.NoteID(filepath.ToSlash(filepath.Clean(path)))
+	if err := m.store.RemoveNote(ctx, id); err != nil {
+		return fmt.Errorf("remove note %q: %w", path, err)
+	}
+	return nil
+}
+
+// indexIfChanged is FullReindex's per-path step: it skips parsing and
+// upserting notes whose fingerprint hasn't changed since the last index run.
+// ReindexNote deliberately bypasses this (a caller reindexing a specific note
+// by name wants it refreshed regardless of its fingerprint) and goes straight
+// through indexSingle instead.
+func (m *VaultIndexManager) indexIfChanged(ctx context.Context, path string) error {
+	note, err := m.vault.LoadNote(ctx, path)
+	if err != nil {
+		return fmt.Errorf("load note %q: %w", path, err)
+	}
+	fingerprint := fingerprintFromNote(note)
+	needsReindex, err := m.store.NeedsReindex(ctx, path, fingerprint)
+	if err != nil {
+		return fmt.Errorf("check fingerprint for %q: %w", path, err)
+	}
+	if !needsReindex {
+		return nil
+	}
+	if err := m.indexNote(ctx, note); err != nil {
+		return err
+	}
+	if err := m.store.RecordFingerprint(ctx, fingerprint); err != nil {
+		return fmt.Errorf("record fingerprint for %q: %w", path, err)
+	}
+	return nil
+}
+
 func (m *VaultIndexManager) indexSingle(ctx context.Context, path string) error {
 	note, err := m.vault.LoadNote(ctx, path)
 	if err != nil {
 		return fmt.Errorf("load note %q: %w", path, err)
 	}
+	if err := m.indexNote(ctx, note); err != nil {
+		return err
+	}
+	return m.store.RecordFingerprint(ctx, fingerprintFromNote(note))
+}
+
+func (m *VaultIndexManager) indexNote(ctx context.Context, note *model.Note) error {
 	parsed, err := m.parser.Parse(ctx, note)
 	if err != nil {
-		return fmt.Errorf("parse note %q: %w", path, err)
+		return fmt.Errorf("parse note %q: %w", note.Path, err)
 	}
 	if err := m.store.UpsertParsedNote(ctx, parsed); err != nil {
-		return fmt.Errorf("index note %q: %w", path, err)
+		return fmt.Errorf("index note %q: %w", note.Path, err)
 	}
 	return nil
 }
@@ -49,11 +49,59 @@ func TestVaultIndexManagerReindexNoteError(t *testing.T) {
 	}
 }
 
+func TestVaultIndexManagerFullReindexSkipsUnchangedNotes(t *testing.T) {
+	v := &fakeVault{
+		paths: []string{"note-a.md"},
+		notes: map[string]*model.Note{
+			"note-a.md": {ID: "a", Path: "note-a.md", Title: "A", Date: time.Now().UTC()},
+		},
+	}
+	countingParser := &countingParser{}
+	store := NewInMemoryIndexStore()
+	manager, err := NewVaultIndexManager(v, countingParser, store)
+	if err != nil {
+		t.Fatalf("NewVaultIndexManager() error = %v", err)
+	}
+
+	if err := manager.FullReindex(context.Background()); err != nil {
+		t.Fatalf("FullReindex() error = %v", err)
+	}
+	if countingParser.calls != 1 {
+		t.Fatalf("parse calls after first FullReindex = %d, want 1", countingParser.calls)
+	}
+
+	if err := manager.FullReindex(context.Background()); err != nil {
+		t.Fatalf("second FullReindex() error = %v", err)
+	}
+	if countingParser.calls != 1 {
+		t.Fatalf("parse calls after second FullReindex = %d, want still 1 (note unchanged)", countingParser.calls)
+	}
+
+	v.notes["note-a.md"].Content = "changed"
+	if err := manager.FullReindex(context.Background()); err != nil {
+		t.Fatalf("third FullReindex() error = %v", err)
+	}
+	if countingParser.calls != 2 {
+		t.Fatalf("parse calls after editing the note = %d, want 2", countingParser.calls)
+	}
+}
+
+type countingParser struct {
+	calls int
+}
+
+func (p *countingParser) Parse(ctx context.Context, note *model.Note) (*parser.ParsedNote, error) {
+	p.calls++
+	return &parser.ParsedNote{Note: note}, nil
+}
+
 type fakeVault struct {
 	paths []string
 	notes map[string]*model.Note
 }
 
+func (f *fakeVault) Name() string { return "fake" }
+
 func (f *fakeVault) Root() string { return "/fake" }
 
 func (f *fakeVault) ListNotePaths(ctx context.Context) ([]string, error) {
@@ -89,6 +137,22 @@ func (f *fakeVault) LoadNotes(ctx context.Context) ([]*model.Note, error) {
 	return result, nil
 }
 
+func (f *fakeVault) WalkNotes(ctx context.Context, fn func(*model.Note) error) error {
+	for _, p := range f.paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		note, err := f.LoadNote(ctx, p)
+		if err != nil {
+			return err
+		}
+		if err := fn(note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type fakeParser struct {
 	err error
 }
@@ -129,6 +193,10 @@ func (f *fakeStore) GetLogEntriesForTask(ctx context.Context, id model.TaskID) (
 	return nil, nil
 }
 
+func (f *fakeStore) ListLogEntries(ctx context.Context, filter LogFilter) ([]model.LogEntry, error) {
+	return nil, nil
+}
+
 func (f *fakeStore) GetMentionsForTask(ctx context.Context, id model.TaskID) ([]model.TaskMention, error) {
 	return nil, nil
 }
@@ -137,8 +205,28 @@ func (f *fakeStore) ListNotes(ctx context.Context, filter NoteFilter) ([]model.N
 	return nil, nil
 }
 
+func (f *fakeStore) ListNotesByMetadata(ctx context.Context, key, value string) ([]model.Note, error) {
+	return nil, nil
+}
+
 func (f *fakeStore) ListTags(ctx context.Context) ([]string, error) { return nil, nil }
 
 func (f *fakeStore) ItemsForTag(ctx context.Context, tag string) (TagItems, bool, error) {
 	return TagItems{}, false, nil
 }
+
+func (f *fakeStore) NeedsReindex(ctx context.Context, path string, fingerprint NoteFingerprint) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeStore) RecordFingerprint(ctx context.Context, fingerprint NoteFingerprint) error {
+	return nil
+}
+
+func (f *fakeStore) ListUnlinkedMentions(ctx context.Context, target model.NoteID) ([]model.UnlinkedMention, error) {
+	return nil, nil
+}
+
+func (f *fakeStore) GetBacklinks(ctx context.Context, noteID model.NoteID) ([]model.NoteLink, error) {
+	return nil, nil
+}
@@ -0,0 +1,265 @@
+package index
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// mentionTarget is one leaf of a mentionMatcher: a note identified by a
+// specific title or alias string.
+type mentionTarget struct {
+	noteID model.NoteID
+	text   string // original-cased title/alias, reported back as MatchedAlias
+	length int    // byte length of the lowercased form inserted into the trie
+}
+
+// mentionNode is one node of the Aho-Corasick trie built over every known
+// note's title and aliases, keyed byte-wise over their lowercased form. A
+// byte-wise (rather than rune-wise) trie keeps construction and traversal
+// simple; it's exact for ASCII text, which covers this vault's titles.
+type mentionNode struct {
+	children map[byte]*mentionNode
+	fail     *mentionNode
+	output   []mentionTarget
+}
+
+func newMentionNode() *mentionNode {
+	return &mentionNode{children: make(map[byte]*mentionNode)}
+}
+
+// mentionMatcher is an Aho-Corasick automaton over every known note's title
+// and aliases. Building it is O(total pattern length); once built, find()
+// scans a note's content in a single pass, so a full-vault scan stays linear
+// in total content length rather than re-scanning per target note.
+type mentionMatcher struct {
+	root *mentionNode
+}
+
+// buildMentionMatcher indexes every note's title and aliases. A single
+// matcher is shared across every source note scanned by
+// InMemoryIndexStore.ListUnlinkedMentions.
+func buildMentionMatcher(notes map[model.NoteID]*model.Note) *mentionMatcher {
+	root := newMentionNode()
+
+	insert := func(noteID model.NoteID, text string) {
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			return
+		}
+		lower := strings.ToLower(trimmed)
+		node := root
+		for i := 0; i < len(lower); i++ {
+			b := lower[i]
+			child, ok := node.children[b]
+			if !ok {
+				child = newMentionNode()
+				node.children[b] = child
+			}
+			node = child
+		}
+		node.output = append(node.output, mentionTarget{noteID: noteID, text: trimmed, length: len(lower)})
+	}
+
+	for id, note := range notes {
+		insert(id, note.Title)
+		for _, alias := range note.Aliases {
+			insert(id, alias)
+		}
+	}
+
+	// Breadth-first fail-link construction: every depth-1 node fails to the
+	// root, and every deeper node fails to whatever node is reached by
+	// following its parent's fail link with the same byte. A node's output
+	// also absorbs its fail target's output, so a match of a shorter
+	// pattern nested inside a longer one is still reported.
+	var queue []*mentionNode
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for b, child := range node.children {
+			queue = append(queue, child)
+			fail := node.fail
+			for fail != nil {
+				if next, ok := fail.children[b]; ok {
+					child.fail = next
+					break
+				}
+				fail = fail.fail
+			}
+			if child.fail == nil {
+				child.fail = root
+			}
+			child.output = append(child.output, child.fail.output...)
+		}
+	}
+
+	return &mentionMatcher{root: root}
+}
+
+// find scans content for every occurrence of any indexed title/alias,
+// invoking fn with the matched target and its byte offsets [start, end) in
+// content.
+func (m *mentionMatcher) find(content string, fn func(target mentionTarget, start, end int)) {
+	lower := strings.ToLower(content)
+	node := m.root
+	for i := 0; i < len(lower); i++ {
+		b := lower[i]
+		for node != m.root {
+			if _, ok := node.children[b]; ok {
+				break
+			}
+			node = node.fail
+		}
+		if child, ok := node.children[b]; ok {
+			node = child
+		} else {
+			node = m.root
+		}
+		if len(node.output) == 0 {
+			continue
+		}
+		end := i + 1
+		for _, target := range node.output {
+			start := end - target.length
+			if start < 0 {
+				continue
+			}
+			fn(target, start, end)
+		}
+	}
+}
+
+var (
+	mentionFencedCodeBlockPattern = regexp.MustCompile("(?s)(```|~~~).*?(```|~~~)")
+	mentionWikiLinkPattern        = regexp.MustCompile(`\[\[[^\]]*\]\]`)
+	mentionMarkdownLinkPattern    = regexp.MustCompile(`\[[^\]]*\]\([^)]*\)`)
+)
+
+// excludedMentionRanges returns the byte ranges in content that should never
+// be treated as unlinked-mention candidates: fenced code blocks and existing
+// [[wikilinks]]/[markdown](links).
+func excludedMentionRanges(content string) [][2]int {
+	var ranges [][2]int
+	for _, pattern := range []*regexp.Regexp{mentionFencedCodeBlockPattern, mentionWikiLinkPattern, mentionMarkdownLinkPattern} {
+		for _, loc := range pattern.FindAllStringIndex(content, -1) {
+			ranges = append(ranges, [2]int{loc[0], loc[1]})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	return ranges
+}
+
+func withinExcludedRange(ranges [][2]int, start, end int) bool {
+	for _, r := range ranges {
+		if start >= r[0] && end <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordBoundaryMatch reports whether the characters immediately outside
+// content[start:end] are not themselves word characters, so e.g. a title
+// "cat" doesn't match inside "category".
+func isWordBoundaryMatch(content string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(content[:start])
+		if isMentionWordRune(r) {
+			return false
+		}
+	}
+	if end < len(content) {
+		r, _ := utf8.DecodeRuneInString(content[end:])
+		if isMentionWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isMentionWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// mentionCandidate pairs a matched target with its byte range within a
+// note's content.
+type mentionCandidate struct {
+	target mentionTarget
+	start  int
+	end    int
+}
+
+// collectMentionCandidates scans content for every occurrence of target that
+// survives the wikilink/markdown-link and word-boundary filters, used by
+// both InMemoryIndexStore.ListUnlinkedMentions and
+// SQLiteIndexStore.ListUnlinkedMentions.
+//
+// When one of target's aliases is itself a substring of another (e.g. a note
+// titled "Project Overview" with alias "Overview", or alias "Project"), the
+// automaton reports every alias whose span overlaps the same occurrence in
+// the text — not only ones sharing an end position, but also a prefix
+// alias, which ends earlier and so would otherwise survive alongside the
+// longer match. Matches are resolved longest-first, discarding any shorter
+// match whose range overlaps one already kept, so a single occurrence is
+// only ever reported once, regardless of whether the shorter alias is a
+// prefix or a suffix of the longer one.
+func collectMentionCandidates(matcher *mentionMatcher, target model.NoteID, content string) []mentionCandidate {
+	excluded := excludedMentionRanges(content)
+	var raw []mentionCandidate
+	matcher.find(content, func(hit mentionTarget, start, end int) {
+		if hit.noteID != target {
+			return
+		}
+		if withinExcludedRange(excluded, start, end) {
+			return
+		}
+		if !isWordBoundaryMatch(content, start, end) {
+			return
+		}
+		raw = append(raw, mentionCandidate{target: hit, start: start, end: end})
+	})
+
+	sort.Slice(raw, func(i, j int) bool {
+		if raw[i].target.length != raw[j].target.length {
+			return raw[i].target.length > raw[j].target.length
+		}
+		return raw[i].start < raw[j].start
+	})
+
+	var candidates []mentionCandidate
+	for _, c := range raw {
+		overlapsKept := false
+		for _, kept := range candidates {
+			if c.start < kept.end && kept.start < c.end {
+				overlapsKept = true
+				break
+			}
+		}
+		if !overlapsKept {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// lineAndExcerptAt returns the 1-based line number containing byte offset
+// start, and that line's trimmed text, for reporting alongside an
+// UnlinkedMention.
+func lineAndExcerptAt(content string, start, end int) (int, string) {
+	line := 1 + strings.Count(content[:start], "\n")
+	lineStart := strings.LastIndexByte(content[:start], '\n') + 1
+	lineEnd := len(content)
+	if rel := strings.IndexByte(content[end:], '\n'); rel >= 0 {
+		lineEnd = end + rel
+	}
+	return line, strings.TrimSpace(content[lineStart:lineEnd])
+}
@@ -0,0 +1,302 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+// Note on placement: the request that motivated this file asked for the
+// Registry to live in internal/vault. It can't: internal/index already
+// imports internal/vault (VaultIndexManager wraps a vault.Vault), and a
+// Registry needs to pair each vault with its own IndexStore, which only
+// internal/index knows about. Putting Registry here, where both
+// dependencies are already in scope, avoids the import cycle that would
+// otherwise result.
+
+// entry pairs one named vault with the VaultIndexManager (and IndexStore)
+// that indexes it.
+type entry struct {
+	vault   vault.Vault
+	manager *VaultIndexManager
+	store   IndexStore
+}
+
+// Registry owns a set of named vaults opened concurrently by a single
+// yapper instance, so note IDs, tasks, and tags can be queried across all
+// of them at once. Each vault keeps its own IndexStore; Registry never
+// shares state between them, so one vault's locking guarantees and
+// determinism (whichever IndexStore implementation it uses) are unaffected
+// by the others.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+}
+
+// NewRegistry builds an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]*entry)}
+}
+
+// Register adds a named vault (and the IndexStore/manager that index it) to
+// the registry. Registering a name that's already present replaces it.
+func (r *Registry) Register(name string, v vault.Vault, manager *VaultIndexManager, store IndexStore) error {
+	if name == "" {
+		return fmt.Errorf("vault name cannot be empty")
+	}
+	if v == nil || manager == nil || store == nil {
+		return fmt.Errorf("vault %q: vault, manager, and store are all required", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[name] = &entry{vault: v, manager: manager, store: store}
+	return nil
+}
+
+// Names returns every registered vault name, sorted for deterministic
+// dispatch order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Vault returns the named vault's manager and store, if registered.
+func (r *Registry) Vault(name string) (*VaultIndexManager, IndexStore, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[name]
+	if !ok {
+		return nil, nil, false
+	}
+	return e.manager, e.store, true
+}
+
+// Root returns the root directory of the named vault, if registered.
+func (r *Registry) Root(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[name]
+	if !ok {
+		return "", false
+	}
+	return e.vault.Root(), true
+}
+
+// ResolveNoteID returns the names of every registered vault that has a note
+// with the given ID. Callers should treat more than one result as
+// ambiguous: the ID alone doesn't identify a unique note across vaults.
+func (r *Registry) ResolveNoteID(ctx context.Context, id model.NoteID) ([]string, error) {
+	var owners []string
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		notes, err := store.ListNotes(ctx, NoteFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("resolve note %q in vault %q: %w", id, name, err)
+		}
+		for _, note := range notes {
+			if note.ID == id {
+				owners = append(owners, name)
+				break
+			}
+		}
+	}
+	return owners, nil
+}
+
+// VaultForPath returns the name of the registered vault whose root contains
+// path, along with path expressed relative to that vault's root. path may
+// be absolute or already vault-relative. It returns ok=false if no
+// registered vault's root contains it.
+func (r *Registry) VaultForPath(path string) (name string, relPath string, ok bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for n, e := range r.entries {
+		rel, err := filepath.Rel(e.vault.Root(), abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return n, filepath.ToSlash(rel), true
+	}
+	return "", "", false
+}
+
+// ListTags returns the deduplicated, sorted set of tags across every
+// registered vault.
+func (r *Registry) ListTags(ctx context.Context) ([]string, error) {
+	seen := make(map[string]struct{})
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		tags, err := store.ListTags(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list tags in vault %q: %w", name, err)
+		}
+		for _, tag := range tags {
+			seen[tag] = struct{}{}
+		}
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// TaggedMention pairs a TaskMention with the name of the vault it came from.
+type TaggedMention struct {
+	VaultName string
+	Mention   model.TaskMention
+}
+
+// GetMentionsForTask dispatches across every registered vault, in
+// deterministic (sorted-name) order, and returns the combined rows each
+// tagged with its originating vault.
+func (r *Registry) GetMentionsForTask(ctx context.Context, id model.TaskID) ([]TaggedMention, error) {
+	var results []TaggedMention
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		mentions, err := store.GetMentionsForTask(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("mentions for task %q in vault %q: %w", id, name, err)
+		}
+		for _, m := range mentions {
+			results = append(results, TaggedMention{VaultName: name, Mention: m})
+		}
+	}
+	return results, nil
+}
+
+// TaggedTask pairs a Task with the name of the vault it came from.
+type TaggedTask struct {
+	VaultName string
+	Task      model.Task
+}
+
+// TaggedLogEntry pairs a LogEntry with the name of the vault it came from.
+type TaggedLogEntry struct {
+	VaultName string
+	LogEntry  model.LogEntry
+}
+
+// TaggedNote pairs a Note with the name of the vault it came from.
+type TaggedNote struct {
+	VaultName string
+	Note      model.Note
+}
+
+// TaggedTagItems pairs a TagItems result with the name of the vault it came
+// from.
+type TaggedTagItems struct {
+	VaultName string
+	Items     TagItems
+}
+
+// ListTasks dispatches filter across every registered vault, in
+// deterministic (sorted-name) order, and returns the combined rows each
+// tagged with its originating vault.
+func (r *Registry) ListTasks(ctx context.Context, filter TaskFilter) ([]TaggedTask, error) {
+	var results []TaggedTask
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		tasks, err := store.ListTasks(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("list tasks in vault %q: %w", name, err)
+		}
+		for _, task := range tasks {
+			results = append(results, TaggedTask{VaultName: name, Task: task})
+		}
+	}
+	return results, nil
+}
+
+// ListLogEntries dispatches filter across every registered vault, in
+// deterministic (sorted-name) order, and returns the combined rows each
+// tagged with its originating vault.
+func (r *Registry) ListLogEntries(ctx context.Context, filter LogFilter) ([]TaggedLogEntry, error) {
+	var results []TaggedLogEntry
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		entries, err := store.ListLogEntries(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("list log entries in vault %q: %w", name, err)
+		}
+		for _, entry := range entries {
+			results = append(results, TaggedLogEntry{VaultName: name, LogEntry: entry})
+		}
+	}
+	return results, nil
+}
+
+// ListNotes dispatches filter across every registered vault, in
+// deterministic (sorted-name) order, and returns the combined rows each
+// tagged with its originating vault.
+func (r *Registry) ListNotes(ctx context.Context, filter NoteFilter) ([]TaggedNote, error) {
+	var results []TaggedNote
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		notes, err := store.ListNotes(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("list notes in vault %q: %w", name, err)
+		}
+		for _, note := range notes {
+			results = append(results, TaggedNote{VaultName: name, Note: note})
+		}
+	}
+	return results, nil
+}
+
+// ItemsForTag queries tag across every registered vault, in deterministic
+// (sorted-name) order, and returns one TaggedTagItems per vault that has a
+// match.
+func (r *Registry) ItemsForTag(ctx context.Context, tag string) ([]TaggedTagItems, error) {
+	var results []TaggedTagItems
+	for _, name := range r.Names() {
+		_, store, ok := r.Vault(name)
+		if !ok {
+			continue
+		}
+		items, found, err := store.ItemsForTag(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("items for tag %q in vault %q: %w", tag, name, err)
+		}
+		if !found {
+			continue
+		}
+		results = append(results, TaggedTagItems{VaultName: name, Items: items})
+	}
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package index
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+func registerVault(t *testing.T, r *Registry, name string, notes ...*model.Note) *InMemoryIndexStore {
+	t.Helper()
+	store := NewInMemoryIndexStore()
+	ctx := context.Background()
+	for _, note := range notes {
+		if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: note}); err != nil {
+			t.Fatalf("seed vault %q: %v", name, err)
+		}
+	}
+	v := &fakeVault{paths: []string{}}
+	manager, err := NewVaultIndexManager(v, &fakeParser{}, store)
+	if err != nil {
+		t.Fatalf("NewVaultIndexManager: %v", err)
+	}
+	if err := r.Register(name, v, manager, store); err != nil {
+		t.Fatalf("Register(%q): %v", name, err)
+	}
+	return store
+}
+
+func TestRegistry_ListNotesAcrossVaults(t *testing.T) {
+	r := NewRegistry()
+	now := time.Now().UTC()
+	registerVault(t, r, "personal", &model.Note{ID: "p-1", Path: "p-1.md", Title: "Personal", Date: now})
+	registerVault(t, r, "work", &model.Note{ID: "w-1", Path: "w-1.md", Title: "Work", Date: now})
+
+	notes, err := r.ListNotes(context.Background(), NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes across vaults, got %d", len(notes))
+	}
+
+	byVault := make(map[string]string)
+	for _, n := range notes {
+		byVault[n.VaultName] = string(n.Note.ID)
+	}
+	if byVault["personal"] != "p-1" || byVault["work"] != "w-1" {
+		t.Fatalf("unexpected vault attribution: %+v", byVault)
+	}
+}
+
+func TestRegistry_ListNotesOrderIsDeterministic(t *testing.T) {
+	r := NewRegistry()
+	now := time.Now().UTC()
+	registerVault(t, r, "zeta", &model.Note{ID: "z-1", Path: "z-1.md", Date: now})
+	registerVault(t, r, "alpha", &model.Note{ID: "a-1", Path: "a-1.md", Date: now})
+
+	notes, err := r.ListNotes(context.Background(), NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes: %v", err)
+	}
+	if len(notes) != 2 || notes[0].VaultName != "alpha" || notes[1].VaultName != "zeta" {
+		t.Fatalf("expected alpha before zeta, got %+v", notes)
+	}
+}
+
+func TestRegistry_ResolveNoteIDFindsAmbiguousOwners(t *testing.T) {
+	r := NewRegistry()
+	now := time.Now().UTC()
+	registerVault(t, r, "personal", &model.Note{ID: "daily.md", Path: "daily.md", Date: now})
+	registerVault(t, r, "work", &model.Note{ID: "daily.md", Path: "daily.md", Date: now})
+
+	owners, err := r.ResolveNoteID(context.Background(), "daily.md")
+	if err != nil {
+		t.Fatalf("ResolveNoteID: %v", err)
+	}
+	if len(owners) != 2 || owners[0] != "personal" || owners[1] != "work" {
+		t.Fatalf("expected both vaults as owners, got %+v", owners)
+	}
+}
@@ -0,0 +1,1108 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	_ "github.com/glebarez/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// SQLiteIndexStore implements IndexStore on top of a SQLite database, so a
+// vault's derived index survives process restarts instead of requiring a
+// full reparse every time. It satisfies the exact same IndexStore interface
+// as InMemoryIndexStore; callers pick between the two via config, the same
+// adapter/core split zk uses for its own storage backends.
+type SQLiteIndexStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteIndexStore opens (and migrates, if necessary) the SQLite database
+// at path. Use ":memory:" for a throwaway store, primarily useful in tests.
+func NewSQLiteIndexStore(path string) (*SQLiteIndexStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite index %q: %w", path, err)
+	}
+	db.SetMaxOpenConns(1) // sqlite is single-writer; keep it simple and serialized.
+
+	store := &SQLiteIndexStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteIndexStore) Close() error {
+	return s.db.Close()
+}
+
+// schemaMigrations lists the index schema's history in order. Each entry is
+// applied inside its own transaction and recorded in schema_version, so
+// restarting against an older on-disk database only ever applies the
+// migrations it's missing.
+var schemaMigrations = [][]string{
+	{ // v1: notes, tasks, log entries, mentions, and a normalized tags join table.
+		`CREATE TABLE IF NOT EXISTS notes (
+			note_id TEXT PRIMARY KEY,
+			path    TEXT NOT NULL,
+			title   TEXT NOT NULL,
+			date    TEXT NOT NULL,
+			content TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS tasks (
+			task_id      TEXT PRIMARY KEY,
+			note_id      TEXT NOT NULL,
+			title        TEXT NOT NULL,
+			status       TEXT NOT NULL,
+			created_at   TEXT NOT NULL,
+			updated_at   TEXT NOT NULL,
+			completed_at TEXT,
+			due_date     TEXT,
+			line         INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS log_entries (
+			log_id    TEXT PRIMARY KEY,
+			note_id   TEXT NOT NULL,
+			line      INTEGER NOT NULL,
+			timestamp TEXT NOT NULL,
+			content   TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS log_task_refs (
+			log_id  TEXT NOT NULL,
+			task_id TEXT NOT NULL,
+			note_id TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS mentions (
+			note_id TEXT NOT NULL,
+			task_id TEXT NOT NULL,
+			line    INTEGER NOT NULL,
+			context TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS tags (
+			tag         TEXT NOT NULL,
+			entity_kind TEXT NOT NULL,
+			entity_id   TEXT NOT NULL,
+			note_id     TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_tags_tag ON tags(tag)`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status)`,
+		`CREATE INDEX IF NOT EXISTS idx_notes_date ON notes(date)`,
+		`CREATE INDEX IF NOT EXISTS idx_log_task_refs_task ON log_task_refs(task_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_mentions_task ON mentions(task_id)`,
+	},
+	{ // v2: per-path fingerprints, so FullReindex can skip unchanged notes.
+		`CREATE TABLE IF NOT EXISTS note_fingerprints (
+			path    TEXT PRIMARY KEY,
+			mtime   TEXT NOT NULL,
+			size    INTEGER NOT NULL,
+			sha256  TEXT NOT NULL
+		)`,
+	},
+	{ // v3: note aliases, for wiki-link resolution and unlinked-mention detection.
+		`ALTER TABLE notes ADD COLUMN aliases TEXT NOT NULL DEFAULT '[]'`,
+	},
+	{ // v4: per-note frontmatter metadata, so ListNotes can filter on arbitrary
+		// frontmatter keys without re-reading every file.
+		`CREATE TABLE IF NOT EXISTS note_metadata (
+			note_id TEXT NOT NULL,
+			key     TEXT NOT NULL,
+			value   TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_note_metadata_key_value ON note_metadata (key, value)`,
+	},
+	{ // v5: [[wiki link]] references, resolved against notes at upsert time the
+		// same way InMemoryIndexStore resolves them, so GetBacklinks can answer
+		// from a table instead of rescanning content.
+		`CREATE TABLE IF NOT EXISTS note_links (
+			source_note_id TEXT NOT NULL,
+			source_line    INTEGER NOT NULL,
+			target_text    TEXT NOT NULL,
+			display        TEXT NOT NULL,
+			target_note_id TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_note_links_target ON note_links(target_note_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_note_links_source ON note_links(source_note_id)`,
+	},
+}
+
+func (s *SQLiteIndexStore) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		return fmt.Errorf("migrate sqlite index: %w", err)
+	}
+
+	var current int
+	row := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`)
+	if err := row.Scan(&current); err != nil {
+		return fmt.Errorf("reading schema version: %w", err)
+	}
+
+	for i := current; i < len(schemaMigrations); i++ {
+		version := i + 1
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("migrate sqlite index to v%d: %w", version, err)
+		}
+		for _, stmt := range schemaMigrations[i] {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migrate sqlite index to v%d: %w", version, err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_version (version, applied_at) VALUES (?, ?)`, version, time.Now().Format(time.RFC3339)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("recording schema v%d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing schema v%d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// UpsertParsedNote deletes any previously indexed rows for parsed.Note.ID and
+// re-inserts the new ones, all inside a single transaction.
+func (s *SQLiteIndexStore) UpsertParsedNote(ctx context.Context, parsed *parser.ParsedNote) error {
+	if parsed == nil || parsed.Note == nil {
+		return errors.New("parsed note cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin upsert for note %q: %w", parsed.Note.ID, err)
+	}
+	defer tx.Rollback()
+
+	note := parsed.Note
+	if err := deleteNoteRowsTx(ctx, tx, note.ID); err != nil {
+		return err
+	}
+
+	aliasesJSON, err := json.Marshal(note.Aliases)
+	if err != nil {
+		return fmt.Errorf("marshal aliases for note %q: %w", note.ID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO notes (note_id, path, title, date, content, aliases) VALUES (?, ?, ?, ?, ?, ?)`,
+		note.ID, note.Path, note.Title, formatTime(note.Date), note.Content, string(aliasesJSON)); err != nil {
+		return fmt.Errorf("insert note %q: %w", note.ID, err)
+	}
+
+	for key, value := range note.Frontmatter {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO note_metadata (note_id, key, value) VALUES (?, ?, ?)`,
+			note.ID, strings.ToLower(key), fmt.Sprint(value)); err != nil {
+			return fmt.Errorf("insert metadata %q for note %q: %w", key, note.ID, err)
+		}
+	}
+
+	for _, task := range parsed.Tasks {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tasks (task_id, note_id, title, status, created_at, updated_at, completed_at, due_date, line) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			task.ID, task.NoteID, task.Title, string(task.Status), formatTime(task.CreatedAt), formatTime(task.UpdatedAt), formatTimePtr(task.CompletedAt), formatTimePtr(task.DueDate), task.Line); err != nil {
+			return fmt.Errorf("insert task %q: %w", task.ID, err)
+		}
+		for _, tag := range normalizeTags(task.Tags) {
+			if err := insertTagTx(ctx, tx, tag, "task", string(task.ID), note.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, entry := range parsed.LogEntries {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO log_entries (log_id, note_id, line, timestamp, content) VALUES (?, ?, ?, ?, ?)`,
+			entry.ID, entry.NoteID, entry.Line, formatTime(entry.Timestamp), entry.Content); err != nil {
+			return fmt.Errorf("insert log entry %q: %w", entry.ID, err)
+		}
+		for _, ref := range entry.TaskRefs {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO log_task_refs (log_id, task_id, note_id) VALUES (?, ?, ?)`,
+				entry.ID, ref, note.ID); err != nil {
+				return fmt.Errorf("insert log task ref %q -> %q: %w", entry.ID, ref, err)
+			}
+		}
+		for _, tag := range normalizeTags(entry.Tags) {
+			if err := insertTagTx(ctx, tx, tag, "log", string(entry.ID), note.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, mention := range parsed.Mentions {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO mentions (note_id, task_id, line, context) VALUES (?, ?, ?, ?)`,
+			mention.NoteID, mention.TaskID, mention.Line, mention.Context); err != nil {
+			return fmt.Errorf("insert mention of %q: %w", mention.TaskID, err)
+		}
+		for _, tag := range normalizeTags(mention.Tags) {
+			if err := insertTagTx(ctx, tx, tag, "mention", mentionKey(mention), note.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, link := range parsed.Links {
+		var targetNoteID sql.NullString
+		if target, ok, err := resolveLinkTx(ctx, tx, link.TargetText); err != nil {
+			return err
+		} else if ok {
+			targetNoteID = sql.NullString{String: string(target), Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO note_links (source_note_id, source_line, target_text, display, target_note_id) VALUES (?, ?, ?, ?, ?)`,
+			link.SourceNoteID, link.SourceLine, link.TargetText, link.Display, targetNoteID); err != nil {
+			return fmt.Errorf("insert link from %q: %w", link.SourceNoteID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// resolveLinkTx resolves wiki-link text against the notes table, trying (in
+// order) an exact NoteID/path match, a case-insensitive path match, an exact
+// title match, a case-insensitive title match, and finally a fuzzy title
+// match — the same tiered fallback InMemoryIndexStore.resolveLinkLocked uses.
+// It returns false if text doesn't resolve to any note.
+func resolveLinkTx(ctx context.Context, tx *sql.Tx, text string) (model.NoteID, bool, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", false, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT note_id, path, title FROM notes`)
+	if err != nil {
+		return "", false, fmt.Errorf("resolve link %q: %w", text, err)
+	}
+	defer rows.Close()
+
+	type candidate struct {
+		id, path, title string
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.path, &c.title); err != nil {
+			return "", false, fmt.Errorf("scan note row resolving link %q: %w", text, err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return "", false, err
+	}
+
+	tiers := []func(candidate) bool{
+		func(c candidate) bool { return c.id == text || c.path == text },
+		func(c candidate) bool { return strings.EqualFold(c.path, text) },
+		func(c candidate) bool { return c.title == text },
+		func(c candidate) bool { return strings.EqualFold(c.title, text) },
+		func(c candidate) bool { return fuzzyTitleKey(c.title) == fuzzyTitleKey(text) },
+	}
+	for _, matches := range tiers {
+		var best *candidate
+		for i := range candidates {
+			c := &candidates[i]
+			if !matches(*c) {
+				continue
+			}
+			if best == nil || c.id < best.id {
+				best = c
+			}
+		}
+		if best != nil {
+			return model.NoteID(best.id), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// GetBacklinks returns every link resolved to noteID, ordered by source note
+// and then source line, matching InMemoryIndexStore.GetBacklinks.
+func (s *SQLiteIndexStore) GetBacklinks(ctx context.Context, noteID model.NoteID) ([]model.NoteLink, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT source_note_id, source_line, target_text, display, target_note_id FROM note_links WHERE target_note_id = ? ORDER BY source_note_id ASC, source_line ASC`, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("backlinks for note %q: %w", noteID, err)
+	}
+	defer rows.Close()
+
+	var links []model.NoteLink
+	for rows.Next() {
+		var link model.NoteLink
+		var sourceNoteID, targetNoteID sql.NullString
+		if err := rows.Scan(&sourceNoteID, &link.SourceLine, &link.TargetText, &link.Display, &targetNoteID); err != nil {
+			return nil, fmt.Errorf("scan backlink row for note %q: %w", noteID, err)
+		}
+		link.SourceNoteID = model.NoteID(sourceNoteID.String)
+		if targetNoteID.Valid {
+			id := model.NoteID(targetNoteID.String)
+			link.TargetNoteID = &id
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RemoveNote deletes every row derived from noteID.
+func (s *SQLiteIndexStore) RemoveNote(ctx context.Context, noteID model.NoteID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin remove note %q: %w", noteID, err)
+	}
+	defer tx.Rollback()
+	if err := deleteNoteRowsTx(ctx, tx, noteID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func deleteNoteRowsTx(ctx context.Context, tx *sql.Tx, noteID model.NoteID) error {
+	stmts := []string{
+		`DELETE FROM tags WHERE note_id = ?`,
+		`DELETE FROM mentions WHERE note_id = ?`,
+		`DELETE FROM log_task_refs WHERE note_id = ?`,
+		`DELETE FROM log_entries WHERE note_id = ?`,
+		`DELETE FROM tasks WHERE note_id = ?`,
+		`DELETE FROM note_metadata WHERE note_id = ?`,
+		`DELETE FROM note_links WHERE source_note_id = ?`,
+		`DELETE FROM notes WHERE note_id = ?`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt, noteID); err != nil {
+			return fmt.Errorf("delete rows for note %q: %w", noteID, err)
+		}
+	}
+	return nil
+}
+
+func insertTagTx(ctx context.Context, tx *sql.Tx, tag, entityKind, entityID string, noteID model.NoteID) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO tags (tag, entity_kind, entity_id, note_id) VALUES (?, ?, ?, ?)`,
+		tag, entityKind, entityID, noteID); err != nil {
+		return fmt.Errorf("insert tag %q for %s %q: %w", tag, entityKind, entityID, err)
+	}
+	return nil
+}
+
+// GetTask returns the task for the provided ID, if present.
+func (s *SQLiteIndexStore) GetTask(ctx context.Context, id model.TaskID) (model.Task, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return model.Task{}, false, err
+	}
+	row := s.db.QueryRowContext(ctx, taskSelectColumns+` FROM tasks WHERE task_id = ?`, id)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Task{}, false, nil
+	}
+	if err != nil {
+		return model.Task{}, false, fmt.Errorf("get task %q: %w", id, err)
+	}
+	tags, err := s.tagsForEntity(ctx, "task", string(id))
+	if err != nil {
+		return model.Task{}, false, err
+	}
+	task.Tags = tags
+	return task, true, nil
+}
+
+// ListTasks lists tasks that satisfy the provided filter, translating each
+// field into a SQL condition rather than filtering in Go.
+func (s *SQLiteIndexStore) ListTasks(ctx context.Context, filter TaskFilter) ([]model.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := taskSelectColumns + ` FROM tasks`
+	var conditions []string
+	var args []any
+
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = "?"
+			args = append(args, string(status))
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if len(filter.NoteIDs) > 0 {
+		placeholders := make([]string, len(filter.NoteIDs))
+		for i, noteID := range filter.NoteIDs {
+			placeholders[i] = "?"
+			args = append(args, noteID)
+		}
+		conditions = append(conditions, fmt.Sprintf("note_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if filter.TagExpr != nil {
+		expr, err := ParseTagExpr(*filter.TagExpr)
+		if err != nil {
+			return nil, err
+		}
+		cond, condArgs := expr.sqlCondition("task", "tasks.task_id")
+		conditions = append(conditions, cond)
+		args = append(args, condArgs...)
+	} else {
+		if len(filter.Tags) > 0 {
+			placeholders := make([]string, len(filter.Tags))
+			for i, tag := range filter.Tags {
+				placeholders[i] = "?"
+				args = append(args, normalizeTag(tag))
+			}
+			conditions = append(conditions, fmt.Sprintf(
+				"task_id IN (SELECT entity_id FROM tags WHERE entity_kind = 'task' AND tag IN (%s))",
+				strings.Join(placeholders, ", ")))
+		}
+		if len(filter.ExcludeTags) > 0 {
+			placeholders := make([]string, len(filter.ExcludeTags))
+			for i, tag := range filter.ExcludeTags {
+				placeholders[i] = "?"
+				args = append(args, normalizeTag(tag))
+			}
+			conditions = append(conditions, fmt.Sprintf(
+				"task_id NOT IN (SELECT entity_id FROM tags WHERE entity_kind = 'task' AND tag IN (%s))",
+				strings.Join(placeholders, ", ")))
+		}
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY task_id ASC"
+
+	// Buffer every row before fetching tags: the store is opened with
+	// SetMaxOpenConns(1), so issuing tagsForEntity's own query while these
+	// rows are still open would block forever waiting for the one
+	// connection this query is holding.
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	var tasks []model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan task row: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range tasks {
+		tags, err := s.tagsForEntity(ctx, "task", string(tasks[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		tasks[i].Tags = tags
+	}
+	return tasks, nil
+}
+
+// GetLogEntriesForTask returns the log entries referencing a given task.
+func (s *SQLiteIndexStore) GetLogEntriesForTask(ctx context.Context, id model.TaskID) ([]model.LogEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Buffer every row before issuing the per-entry ref/tag queries below;
+	// see the comment in ListTasks for why (SetMaxOpenConns(1)).
+	rows, err := s.db.QueryContext(ctx, logEntrySelectColumns+`
+		FROM log_entries
+		WHERE log_id IN (SELECT log_id FROM log_task_refs WHERE task_id = ?)
+		ORDER BY log_id ASC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get log entries for task %q: %w", id, err)
+	}
+	var entries []model.LogEntry
+	for rows.Next() {
+		entry, err := scanLogEntry(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan log entry row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range entries {
+		refs, err := s.taskRefsForLogEntry(ctx, entries[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		entries[i].TaskRefs = refs
+		tags, err := s.tagsForEntity(ctx, "log", string(entries[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		entries[i].Tags = tags
+	}
+	return entries, nil
+}
+
+// ListLogEntries lists log entries that satisfy the provided filter,
+// translating each field into a SQL condition rather than filtering in Go,
+// mirroring ListTasks.
+func (s *SQLiteIndexStore) ListLogEntries(ctx context.Context, filter LogFilter) ([]model.LogEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := logEntrySelectColumns + ` FROM log_entries`
+	var conditions []string
+	var args []any
+
+	if len(filter.NoteIDs) > 0 {
+		placeholders := make([]string, len(filter.NoteIDs))
+		for i, noteID := range filter.NoteIDs {
+			placeholders[i] = "?"
+			args = append(args, noteID)
+		}
+		conditions = append(conditions, fmt.Sprintf("note_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if filter.TagExpr != nil {
+		expr, err := ParseTagExpr(*filter.TagExpr)
+		if err != nil {
+			return nil, err
+		}
+		cond, condArgs := expr.sqlCondition("log", "log_entries.log_id")
+		conditions = append(conditions, cond)
+		args = append(args, condArgs...)
+	} else {
+		if len(filter.Tags) > 0 {
+			placeholders := make([]string, len(filter.Tags))
+			for i, tag := range filter.Tags {
+				placeholders[i] = "?"
+				args = append(args, normalizeTag(tag))
+			}
+			conditions = append(conditions, fmt.Sprintf(
+				"log_id IN (SELECT entity_id FROM tags WHERE entity_kind = 'log' AND tag IN (%s))",
+				strings.Join(placeholders, ", ")))
+		}
+		if len(filter.ExcludeTags) > 0 {
+			placeholders := make([]string, len(filter.ExcludeTags))
+			for i, tag := range filter.ExcludeTags {
+				placeholders[i] = "?"
+				args = append(args, normalizeTag(tag))
+			}
+			conditions = append(conditions, fmt.Sprintf(
+				"log_id NOT IN (SELECT entity_id FROM tags WHERE entity_kind = 'log' AND tag IN (%s))",
+				strings.Join(placeholders, ", ")))
+		}
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY log_id ASC"
+
+	// Buffer every row before issuing the per-entry ref/tag queries below;
+	// see the comment in ListTasks for why (SetMaxOpenConns(1)).
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list log entries: %w", err)
+	}
+	var entries []model.LogEntry
+	for rows.Next() {
+		entry, err := scanLogEntry(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan log entry row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range entries {
+		refs, err := s.taskRefsForLogEntry(ctx, entries[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		entries[i].TaskRefs = refs
+		tags, err := s.tagsForEntity(ctx, "log", string(entries[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		entries[i].Tags = tags
+	}
+	return entries, nil
+}
+
+// GetMentionsForTask returns the mentions pointing to a given task.
+func (s *SQLiteIndexStore) GetMentionsForTask(ctx context.Context, id model.TaskID) ([]model.TaskMention, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Buffer every row before issuing tagsForEntity's own query below; see
+	// the comment in ListTasks for why (SetMaxOpenConns(1)).
+	rows, err := s.db.QueryContext(ctx, `SELECT note_id, task_id, line, context FROM mentions WHERE task_id = ? ORDER BY note_id ASC, line ASC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get mentions for task %q: %w", id, err)
+	}
+	var mentions []model.TaskMention
+	for rows.Next() {
+		var m model.TaskMention
+		if err := rows.Scan(&m.NoteID, &m.TaskID, &m.Line, &m.Context); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan mention row: %w", err)
+		}
+		mentions = append(mentions, m)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range mentions {
+		tags, err := s.tagsForEntity(ctx, "mention", mentionKey(mentions[i]))
+		if err != nil {
+			return nil, err
+		}
+		mentions[i].Tags = tags
+	}
+	return mentions, nil
+}
+
+// ListNotes returns every note in the index ordered by descending date,
+// constrained to the provided date range.
+func (s *SQLiteIndexStore) ListNotes(ctx context.Context, filter NoteFilter) ([]model.Note, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT note_id, path, title, date, content, aliases FROM notes`
+	var conditions []string
+	var args []any
+	if filter.Start != nil {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, formatTime(*filter.Start))
+	}
+	if filter.End != nil {
+		conditions = append(conditions, "date <= ?")
+		args = append(args, formatTime(*filter.End))
+	}
+	metadataKeys := make([]string, 0, len(filter.Metadata))
+	for key := range filter.Metadata {
+		metadataKeys = append(metadataKeys, key)
+	}
+	sort.Strings(metadataKeys)
+	for _, key := range metadataKeys {
+		conditions = append(conditions, "note_id IN (SELECT note_id FROM note_metadata WHERE key = ? AND value = ?)")
+		args = append(args, strings.ToLower(key), filter.Metadata[key])
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY date DESC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []model.Note
+	for rows.Next() {
+		var note model.Note
+		var date, aliasesJSON string
+		if err := rows.Scan(&note.ID, &note.Path, &note.Title, &date, &note.Content, &aliasesJSON); err != nil {
+			return nil, fmt.Errorf("scan note row: %w", err)
+		}
+		note.Date = parseTimeOrZero(date)
+		if err := json.Unmarshal([]byte(aliasesJSON), &note.Aliases); err != nil {
+			return nil, fmt.Errorf("unmarshal aliases for note %q: %w", note.ID, err)
+		}
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range notes {
+		metadata, err := s.metadataForNote(ctx, notes[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		notes[i].Frontmatter = metadata
+	}
+	return notes, nil
+}
+
+// metadataForNote reconstitutes a note's indexed frontmatter as a
+// map[string]any. Values are stored (and returned) as their string form;
+// SQLiteIndexStore's note_metadata table exists to answer NoteFilter.Metadata
+// queries cheaply, not to round-trip the original YAML types.
+func (s *SQLiteIndexStore) metadataForNote(ctx context.Context, noteID model.NoteID) (map[string]any, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM note_metadata WHERE note_id = ?`, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("metadata for note %q: %w", noteID, err)
+	}
+	defer rows.Close()
+
+	var metadata map[string]any
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scan metadata row: %w", err)
+		}
+		if metadata == nil {
+			metadata = make(map[string]any)
+		}
+		metadata[key] = value
+	}
+	return metadata, rows.Err()
+}
+
+// ListNotesByMetadata implements IndexStore.
+func (s *SQLiteIndexStore) ListNotesByMetadata(ctx context.Context, key, value string) ([]model.Note, error) {
+	return s.ListNotes(ctx, NoteFilter{Metadata: map[string]string{key: value}})
+}
+
+// ListTags returns the unique set of tags known to the index sorted
+// lexicographically.
+func (s *SQLiteIndexStore) ListTags(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT tag FROM tags ORDER BY tag ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("scan tag row: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// ItemsForTag returns every indexed entity linked to the provided tag. tag
+// may be an exact (leaf) tag or a hierarchical prefix (e.g. "project"
+// matches both "project" and "project/yapper"); results from every matching
+// tag are unioned together.
+func (s *SQLiteIndexStore) ItemsForTag(ctx context.Context, tag string) (TagItems, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return TagItems{}, false, err
+	}
+	tag = normalizeTag(tag)
+	if tag == "" {
+		return TagItems{}, false, errors.New("tag cannot be empty")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT entity_kind, entity_id, tag FROM tags WHERE tag = ? OR tag LIKE ?`, tag, tag+"/%")
+	if err != nil {
+		return TagItems{}, false, fmt.Errorf("items for tag %q: %w", tag, err)
+	}
+	taskTags := make(map[model.TaskID]string)
+	logTags := make(map[model.LogEntryID]string)
+	mentionTags := make(map[string]string)
+	for rows.Next() {
+		var kind, entityID, matchedTag string
+		if err := rows.Scan(&kind, &entityID, &matchedTag); err != nil {
+			rows.Close()
+			return TagItems{}, false, fmt.Errorf("scan tag entity row: %w", err)
+		}
+		switch kind {
+		case "task":
+			taskTags[model.TaskID(entityID)] = matchedTag
+		case "log":
+			logTags[model.LogEntryID(entityID)] = matchedTag
+		case "mention":
+			mentionTags[entityID] = matchedTag
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return TagItems{}, false, err
+	}
+	rows.Close()
+
+	if len(taskTags) == 0 && len(logTags) == 0 && len(mentionTags) == 0 {
+		return TagItems{}, false, nil
+	}
+
+	items := TagItems{Tag: tag}
+	for id := range taskTags {
+		task, ok, err := s.GetTask(ctx, id)
+		if err != nil {
+			return TagItems{}, false, err
+		}
+		if ok {
+			items.Tasks = append(items.Tasks, task)
+		}
+	}
+	for id, matchedTag := range logTags {
+		row := s.db.QueryRowContext(ctx, logEntrySelectColumns+` FROM log_entries WHERE log_id = ?`, id)
+		entry, err := scanLogEntry(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return TagItems{}, false, fmt.Errorf("load log entry %q: %w", id, err)
+		}
+		refs, err := s.taskRefsForLogEntry(ctx, entry.ID)
+		if err != nil {
+			return TagItems{}, false, err
+		}
+		entry.TaskRefs = refs
+		entry.Tags = []string{matchedTag}
+		items.LogEntries = append(items.LogEntries, entry)
+	}
+	for key, matchedTag := range mentionTags {
+		mention, ok, err := s.mentionByKey(ctx, key)
+		if err != nil {
+			return TagItems{}, false, err
+		}
+		if ok {
+			mention.Tags = []string{matchedTag}
+			items.Mentions = append(items.Mentions, mention)
+		}
+	}
+
+	sort.Slice(items.Tasks, func(i, j int) bool { return items.Tasks[i].ID < items.Tasks[j].ID })
+	sort.Slice(items.LogEntries, func(i, j int) bool { return items.LogEntries[i].ID < items.LogEntries[j].ID })
+	sort.Slice(items.Mentions, func(i, j int) bool {
+		if items.Mentions[i].TaskID == items.Mentions[j].TaskID {
+			if items.Mentions[i].NoteID == items.Mentions[j].NoteID {
+				return items.Mentions[i].Line < items.Mentions[j].Line
+			}
+			return items.Mentions[i].NoteID < items.Mentions[j].NoteID
+		}
+		return items.Mentions[i].TaskID < items.Mentions[j].TaskID
+	})
+
+	return items, true, nil
+}
+
+// NeedsReindex reports whether path's last recorded fingerprint differs from
+// fingerprint (or none was recorded yet).
+func (s *SQLiteIndexStore) NeedsReindex(ctx context.Context, path string, fingerprint NoteFingerprint) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	row := s.db.QueryRowContext(ctx, `SELECT mtime, size, sha256 FROM note_fingerprints WHERE path = ?`, path)
+	var mtime, sha256Sum string
+	var size int64
+	err := row.Scan(&mtime, &size, &sha256Sum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("load fingerprint for %q: %w", path, err)
+	}
+	existing := NoteFingerprint{Path: path, ModTime: parseTimeOrZero(mtime), Size: size, SHA256: sha256Sum}
+	return existing != fingerprint, nil
+}
+
+// RecordFingerprint stores fingerprint as path's last-indexed state.
+func (s *SQLiteIndexStore) RecordFingerprint(ctx context.Context, fingerprint NoteFingerprint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO note_fingerprints (path, mtime, size, sha256) VALUES (?, ?, ?, ?)
+		ON CONFLICT(path) DO UPDATE SET mtime = excluded.mtime, size = excluded.size, sha256 = excluded.sha256`,
+		fingerprint.Path, formatTime(fingerprint.ModTime), fingerprint.Size, fingerprint.SHA256)
+	if err != nil {
+		return fmt.Errorf("record fingerprint for %q: %w", fingerprint.Path, err)
+	}
+	return nil
+}
+
+// ListUnlinkedMentions finds plain-text occurrences of target's title or any
+// alias inside other notes' bodies that aren't already a [[wikilink]] or
+// [markdown](link) to it. Unlike InMemoryIndexStore, there's no cached
+// matcher to keep warm between calls, so it builds one from a fresh
+// ListNotes snapshot each time; reuse the same mentions.go matching logic
+// either way.
+func (s *SQLiteIndexStore) ListUnlinkedMentions(ctx context.Context, target model.NoteID) ([]model.UnlinkedMention, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	allNotes, err := s.ListNotes(ctx, NoteFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list notes for unlinked mentions: %w", err)
+	}
+	notes := make(map[model.NoteID]*model.Note, len(allNotes))
+	var targetExists bool
+	for i := range allNotes {
+		note := &allNotes[i]
+		notes[note.ID] = note
+		if note.ID == target {
+			targetExists = true
+		}
+	}
+	if !targetExists {
+		return nil, nil
+	}
+
+	matcher := buildMentionMatcher(notes)
+	var mentions []model.UnlinkedMention
+	for sourceID, note := range notes {
+		if sourceID == target {
+			continue
+		}
+		for _, c := range collectMentionCandidates(matcher, target, note.Content) {
+			line, excerpt := lineAndExcerptAt(note.Content, c.start, c.end)
+			mentions = append(mentions, model.UnlinkedMention{
+				SourceNoteID: sourceID,
+				TargetNoteID: target,
+				Line:         line,
+				Excerpt:      excerpt,
+				MatchedAlias: c.target.text,
+			})
+		}
+	}
+
+	sort.Slice(mentions, func(i, j int) bool {
+		if mentions[i].SourceNoteID != mentions[j].SourceNoteID {
+			return mentions[i].SourceNoteID < mentions[j].SourceNoteID
+		}
+		return mentions[i].Line < mentions[j].Line
+	})
+	return mentions, nil
+}
+
+func (s *SQLiteIndexStore) mentionByKey(ctx context.Context, key string) (model.TaskMention, bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT note_id, task_id, line, context FROM mentions`)
+	if err != nil {
+		return model.TaskMention{}, false, fmt.Errorf("load mentions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m model.TaskMention
+		if err := rows.Scan(&m.NoteID, &m.TaskID, &m.Line, &m.Context); err != nil {
+			return model.TaskMention{}, false, fmt.Errorf("scan mention row: %w", err)
+		}
+		if mentionKey(m) == key {
+			return m, true, nil
+		}
+	}
+	return model.TaskMention{}, false, rows.Err()
+}
+
+func (s *SQLiteIndexStore) tagsForEntity(ctx context.Context, entityKind, entityID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT tag FROM tags WHERE entity_kind = ? AND entity_id = ? ORDER BY tag ASC`, entityKind, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("tags for %s %q: %w", entityKind, entityID, err)
+	}
+	defer rows.Close()
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("scan tag row: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (s *SQLiteIndexStore) taskRefsForLogEntry(ctx context.Context, logID model.LogEntryID) ([]model.TaskID, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT task_id FROM log_task_refs WHERE log_id = ? ORDER BY task_id ASC`, logID)
+	if err != nil {
+		return nil, fmt.Errorf("task refs for log entry %q: %w", logID, err)
+	}
+	defer rows.Close()
+	var refs []model.TaskID
+	for rows.Next() {
+		var taskID model.TaskID
+		if err := rows.Scan(&taskID); err != nil {
+			return nil, fmt.Errorf("scan log task ref row: %w", err)
+		}
+		refs = append(refs, taskID)
+	}
+	return refs, rows.Err()
+}
+
+const taskSelectColumns = `SELECT task_id, note_id, title, status, created_at, updated_at, completed_at, due_date, line`
+
+const logEntrySelectColumns = `SELECT log_id, note_id, line, timestamp, content`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(scanner rowScanner) (model.Task, error) {
+	var task model.Task
+	var noteID, taskID, status, createdAt, updatedAt string
+	var completedAt, dueDate sql.NullString
+	if err := scanner.Scan(&taskID, &noteID, &task.Title, &status, &createdAt, &updatedAt, &completedAt, &dueDate, &task.Line); err != nil {
+		return model.Task{}, err
+	}
+	task.ID = model.TaskID(taskID)
+	task.NoteID = model.NoteID(noteID)
+	task.Status = model.TaskStatus(status)
+	task.CreatedAt = parseTimeOrZero(createdAt)
+	task.UpdatedAt = parseTimeOrZero(updatedAt)
+	if completedAt.Valid {
+		t := parseTimeOrZero(completedAt.String)
+		task.CompletedAt = &t
+	}
+	if dueDate.Valid {
+		t := parseTimeOrZero(dueDate.String)
+		task.DueDate = &t
+	}
+	return task, nil
+}
+
+func scanLogEntry(scanner rowScanner) (model.LogEntry, error) {
+	var entry model.LogEntry
+	var logID, noteID, timestamp string
+	if err := scanner.Scan(&logID, &noteID, &entry.Line, &timestamp, &entry.Content); err != nil {
+		return model.LogEntry{}, err
+	}
+	entry.ID = model.LogEntryID(logID)
+	entry.NoteID = model.NoteID(noteID)
+	entry.Timestamp = parseTimeOrZero(timestamp)
+	return entry, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) sql.NullString {
+	if t == nil || t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(*t), Valid: true}
+}
+
+func parseTimeOrZero(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,472 @@
+package index
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+func TestSQLiteIndexStoreUpsertQueryAndRemove(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	note := &model.Note{
+		ID:    "note-1",
+		Path:  "note-1.md",
+		Title: "Note 1",
+		Date:  time.Now().UTC(),
+	}
+	task := model.Task{
+		ID:        "task-1",
+		NoteID:    note.ID,
+		Title:     "Finish phase 3",
+		Status:    model.TaskStatusInProgress,
+		Tags:      []string{"Work", "PROJECT/Yapper"},
+		CreatedAt: time.Now().UTC(),
+		Line:      10,
+	}
+	logEntry := model.LogEntry{
+		ID:        "log-1",
+		NoteID:    note.ID,
+		Line:      20,
+		Timestamp: time.Now().UTC(),
+		Content:   "Mentioned task in log",
+		Tags:      []string{"Work"},
+		TaskRefs:  []model.TaskID{task.ID},
+	}
+	mention := model.TaskMention{
+		TaskID:  task.ID,
+		NoteID:  note.ID,
+		Line:    30,
+		Context: "Follow up on #Work task",
+		Tags:    []string{"WORK"},
+	}
+
+	parsed := &parser.ParsedNote{
+		Note:       note,
+		Tasks:      []model.Task{task},
+		LogEntries: []model.LogEntry{logEntry},
+		Mentions:   []model.TaskMention{mention},
+	}
+
+	if err := store.UpsertParsedNote(ctx, parsed); err != nil {
+		t.Fatalf("UpsertParsedNote() error = %v", err)
+	}
+
+	gotTask, ok, err := store.GetTask(ctx, task.ID)
+	if err != nil || !ok {
+		t.Fatalf("GetTask() error = %v, ok=%v", err, ok)
+	}
+	if gotTask.Title != task.Title {
+		t.Fatalf("GetTask() title = %s, want %s", gotTask.Title, task.Title)
+	}
+
+	tasks, err := store.ListTasks(ctx, TaskFilter{Statuses: []model.TaskStatus{model.TaskStatusInProgress}})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Fatalf("ListTasks() = %+v, want task %s", tasks, task.ID)
+	}
+
+	tasksByTag, err := store.ListTasks(ctx, TaskFilter{Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("ListTasks() by tag error = %v", err)
+	}
+	if len(tasksByTag) != 1 || tasksByTag[0].ID != task.ID {
+		t.Fatalf("ListTasks() by tag = %+v, want task %s", tasksByTag, task.ID)
+	}
+
+	exclWork := "-work"
+	tasksByExpr, err := store.ListTasks(ctx, TaskFilter{TagExpr: &exclWork})
+	if err != nil {
+		t.Fatalf("ListTasks() by tag expr error = %v", err)
+	}
+	if len(tasksByExpr) != 0 {
+		t.Fatalf("ListTasks(TagExpr=-work) = %+v, want none (task is tagged work)", tasksByExpr)
+	}
+
+	tasksExcluded, err := store.ListTasks(ctx, TaskFilter{ExcludeTags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("ListTasks() with ExcludeTags error = %v", err)
+	}
+	if len(tasksExcluded) != 0 {
+		t.Fatalf("ListTasks(ExcludeTags=[work]) = %+v, want none (task is tagged work)", tasksExcluded)
+	}
+
+	notes, err := store.ListNotes(ctx, NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != note.ID {
+		t.Fatalf("ListNotes() = %+v, want note %s", notes, note.ID)
+	}
+
+	entries, err := store.GetLogEntriesForTask(ctx, task.ID)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("GetLogEntriesForTask() error = %v len=%d", err, len(entries))
+	}
+	mentions, err := store.GetMentionsForTask(ctx, task.ID)
+	if err != nil || len(mentions) != 1 {
+		t.Fatalf("GetMentionsForTask() error = %v len=%d", err, len(mentions))
+	}
+
+	logsByTag, err := store.ListLogEntries(ctx, LogFilter{Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("ListLogEntries() by tag error = %v", err)
+	}
+	if len(logsByTag) != 1 || logsByTag[0].ID != logEntry.ID {
+		t.Fatalf("ListLogEntries() by tag = %+v, want log %s", logsByTag, logEntry.ID)
+	}
+
+	exclWorkLogs := "-work"
+	logsByExpr, err := store.ListLogEntries(ctx, LogFilter{TagExpr: &exclWorkLogs})
+	if err != nil {
+		t.Fatalf("ListLogEntries() by tag expr error = %v", err)
+	}
+	if len(logsByExpr) != 0 {
+		t.Fatalf("ListLogEntries(TagExpr=-work) = %+v, want none (entry is tagged work)", logsByExpr)
+	}
+
+	tags, err := store.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	wantTags := []string{"project/yapper", "work"}
+	if len(tags) != len(wantTags) {
+		t.Fatalf("ListTags() = %+v, want %v", tags, wantTags)
+	}
+	for i, tag := range wantTags {
+		if tags[i] != tag {
+			t.Fatalf("ListTags() sorted order mismatch: got %v want %v", tags, wantTags)
+		}
+	}
+
+	items, ok, err := store.ItemsForTag(ctx, "work")
+	if err != nil || !ok {
+		t.Fatalf("ItemsForTag() error = %v ok=%v", err, ok)
+	}
+	if len(items.Tasks) != 1 || len(items.LogEntries) != 1 || len(items.Mentions) != 1 {
+		t.Fatalf("ItemsForTag() unexpected counts: %+v", items)
+	}
+
+	if err := store.RemoveNote(ctx, note.ID); err != nil {
+		t.Fatalf("RemoveNote() error = %v", err)
+	}
+	tasks, err = store.ListTasks(ctx, TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() after remove error = %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("ListTasks() after remove = %+v, want empty", tasks)
+	}
+
+	_, ok, err = store.ItemsForTag(ctx, "work")
+	if err != nil {
+		t.Fatalf("ItemsForTag() after remove err = %v", err)
+	}
+	if ok {
+		t.Fatalf("ItemsForTag() after remove returned ok=true, want false")
+	}
+}
+
+// TestSQLiteIndexStoreItemsForTagPrefixMatch validates that querying a
+// hierarchical tag prefix (e.g. "project") returns entities tagged with that
+// prefix's full leaf form (e.g. "project/yapper"), unioned with any exact
+// match on the prefix itself.
+func TestSQLiteIndexStoreItemsForTagPrefixMatch(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	note := &model.Note{ID: "note-1", Path: "note-1.md", Title: "Note 1", Date: time.Now().UTC()}
+	task := model.Task{
+		ID:        "task-1",
+		NoteID:    note.ID,
+		Title:     "Ship yapper",
+		Status:    model.TaskStatusTodo,
+		Tags:      []string{"project/yapper"},
+		CreatedAt: time.Now().UTC(),
+		Line:      1,
+	}
+	parsed := &parser.ParsedNote{Note: note, Tasks: []model.Task{task}}
+
+	if err := store.UpsertParsedNote(ctx, parsed); err != nil {
+		t.Fatalf("UpsertParsedNote() error = %v", err)
+	}
+
+	items, ok, err := store.ItemsForTag(ctx, "project")
+	if err != nil || !ok {
+		t.Fatalf("ItemsForTag(project) error = %v ok=%v", err, ok)
+	}
+	if len(items.Tasks) != 1 || items.Tasks[0].ID != task.ID {
+		t.Fatalf("ItemsForTag(project) = %+v, want task %s", items, task.ID)
+	}
+
+	items, ok, err = store.ItemsForTag(ctx, "project/yapper")
+	if err != nil || !ok {
+		t.Fatalf("ItemsForTag(project/yapper) error = %v ok=%v", err, ok)
+	}
+	if len(items.Tasks) != 1 || items.Tasks[0].ID != task.ID {
+		t.Fatalf("ItemsForTag(project/yapper) = %+v, want task %s", items, task.ID)
+	}
+
+	if _, ok, err := store.ItemsForTag(ctx, "unrelated"); err != nil || ok {
+		t.Fatalf("ItemsForTag(unrelated) = ok=%v err=%v, want ok=false", ok, err)
+	}
+}
+
+func TestSQLiteIndexStoreMigrationIsIdempotent(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+
+	if err := store.migrate(); err != nil {
+		t.Fatalf("re-running migrate() should be a no-op, got error = %v", err)
+	}
+}
+
+func TestSQLiteIndexStoreNeedsReindex(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	fp := NoteFingerprint{Path: "a.md", ModTime: time.Now().UTC().Truncate(time.Second), Size: 42, SHA256: "deadbeef"}
+
+	needs, err := store.NeedsReindex(ctx, fp.Path, fp)
+	if err != nil {
+		t.Fatalf("NeedsReindex() error = %v", err)
+	}
+	if !needs {
+		t.Fatal("NeedsReindex() = false for a path with no recorded fingerprint, want true")
+	}
+
+	if err := store.RecordFingerprint(ctx, fp); err != nil {
+		t.Fatalf("RecordFingerprint() error = %v", err)
+	}
+
+	needs, err = store.NeedsReindex(ctx, fp.Path, fp)
+	if err != nil {
+		t.Fatalf("NeedsReindex() error = %v", err)
+	}
+	if needs {
+		t.Fatal("NeedsReindex() = true for an unchanged fingerprint, want false")
+	}
+
+	changed := fp
+	changed.SHA256 = "feedface"
+	needs, err = store.NeedsReindex(ctx, changed.Path, changed)
+	if err != nil {
+		t.Fatalf("NeedsReindex() error = %v", err)
+	}
+	if !needs {
+		t.Fatal("NeedsReindex() = false for a changed fingerprint, want true")
+	}
+
+	// RecordFingerprint overwrites the previous fingerprint for the same path.
+	if err := store.RecordFingerprint(ctx, changed); err != nil {
+		t.Fatalf("RecordFingerprint() error = %v", err)
+	}
+	needs, err = store.NeedsReindex(ctx, changed.Path, changed)
+	if err != nil {
+		t.Fatalf("NeedsReindex() error = %v", err)
+	}
+	if needs {
+		t.Fatal("NeedsReindex() = true after recording the updated fingerprint, want false")
+	}
+}
+
+func TestSQLiteIndexStoreListUnlinkedMentions(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Aliases: []string{"Overview"}, Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{
+		ID:    "notes/daily.md",
+		Path:  "notes/daily.md",
+		Title: "Daily",
+		Content: "Talked about Project Overview today.\n" +
+			"Already linked via [[Project Overview]], so that one shouldn't count twice.\n" +
+			"A code block shouldn't count either: ```Overview```\n" +
+			"categoryOverview should not match as a substring.",
+		Date: time.Now().UTC(),
+	}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	mentions, err := store.ListUnlinkedMentions(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("ListUnlinkedMentions() error = %v", err)
+	}
+	if len(mentions) != 1 {
+		t.Fatalf("ListUnlinkedMentions() = %+v, want exactly one unlinked mention", mentions)
+	}
+	if mentions[0].SourceNoteID != source.ID || mentions[0].Line != 1 || mentions[0].MatchedAlias != "Project Overview" {
+		t.Fatalf("ListUnlinkedMentions()[0] = %+v, want source %s line 1 matching %q", mentions[0], source.ID, "Project Overview")
+	}
+
+	if mentions, err := store.ListUnlinkedMentions(ctx, "does not exist"); err != nil || mentions != nil {
+		t.Fatalf("ListUnlinkedMentions() for unknown target = (%+v, %v), want (nil, nil)", mentions, err)
+	}
+}
+
+// TestSQLiteIndexStoreListUnlinkedMentionsPrefixAlias covers an alias that
+// is a *prefix* of another alias/title rather than a suffix: the two
+// matches share a start position but different end positions, so dedup
+// logic keyed only on end position would let both through.
+func TestSQLiteIndexStoreListUnlinkedMentionsPrefixAlias(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Aliases: []string{"Project"}, Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{
+		ID:      "notes/daily.md",
+		Path:    "notes/daily.md",
+		Title:   "Daily",
+		Content: "Talked about Project Overview today.\n",
+		Date:    time.Now().UTC(),
+	}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	mentions, err := store.ListUnlinkedMentions(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("ListUnlinkedMentions() error = %v", err)
+	}
+	if len(mentions) != 1 {
+		t.Fatalf("ListUnlinkedMentions() = %+v, want exactly one unlinked mention", mentions)
+	}
+	if mentions[0].MatchedAlias != "Project Overview" {
+		t.Fatalf("ListUnlinkedMentions()[0].MatchedAlias = %q, want the longer title to win over its own prefix", mentions[0].MatchedAlias)
+	}
+}
+
+// TestSQLiteIndexStoreLinksAndBacklinks validates that [[wiki links]] are
+// resolved against the notes table at upsert time, that GetBacklinks answers
+// from the note_links table, and that removing the source note leaves the
+// backlink dangling rather than erroring, matching InMemoryIndexStore.
+func TestSQLiteIndexStoreLinksAndBacklinks(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	target := &model.Note{ID: "notes/project-overview.md", Path: "notes/project-overview.md", Title: "Project Overview", Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: target}); err != nil {
+		t.Fatalf("UpsertParsedNote(target) error = %v", err)
+	}
+
+	source := &model.Note{ID: "notes/daily.md", Path: "notes/daily.md", Title: "Daily", Date: time.Now().UTC()}
+	link := model.NoteLink{SourceNoteID: source.ID, SourceLine: 3, TargetText: "project overview", Display: "the overview doc"}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: source, Links: []model.NoteLink{link}}); err != nil {
+		t.Fatalf("UpsertParsedNote(source) error = %v", err)
+	}
+
+	backlinks, err := store.GetBacklinks(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetBacklinks() error = %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].SourceNoteID != source.ID || backlinks[0].Display != "the overview doc" || backlinks[0].TargetNoteID == nil || *backlinks[0].TargetNoteID != target.ID {
+		t.Fatalf("GetBacklinks() = %+v, want a resolved link from %s", backlinks, source.ID)
+	}
+
+	if err := store.RemoveNote(ctx, source.ID); err != nil {
+		t.Fatalf("RemoveNote(source) error = %v", err)
+	}
+	backlinks, err = store.GetBacklinks(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("GetBacklinks() after remove error = %v", err)
+	}
+	if len(backlinks) != 0 {
+		t.Fatalf("GetBacklinks() after remove = %+v, want empty", backlinks)
+	}
+}
+
+func TestSQLiteIndexStoreListNotesMetadataFilter(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	high := &model.Note{ID: "a.md", Path: "a.md", Title: "A", Date: time.Now().UTC(), Frontmatter: map[string]any{"priority": "high"}}
+	low := &model.Note{ID: "b.md", Path: "b.md", Title: "B", Date: time.Now().UTC(), Frontmatter: map[string]any{"priority": "low"}}
+	for _, n := range []*model.Note{high, low} {
+		if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: n}); err != nil {
+			t.Fatalf("UpsertParsedNote(%s) error = %v", n.ID, err)
+		}
+	}
+
+	notes, err := store.ListNotes(ctx, NoteFilter{Metadata: map[string]string{"Priority": "high"}})
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != high.ID || notes[0].Frontmatter["priority"] != "high" {
+		t.Fatalf("ListNotes() = %+v, want only %s with priority=high", notes, high.ID)
+	}
+
+	if notes, err := store.ListNotes(ctx, NoteFilter{Metadata: map[string]string{"priority": "nonexistent"}}); err != nil || len(notes) != 0 {
+		t.Fatalf("ListNotes() = (%+v, %v), want (empty, nil)", notes, err)
+	}
+}
+
+func TestSQLiteIndexStoreListNotesByMetadata(t *testing.T) {
+	store, err := NewSQLiteIndexStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteIndexStore() error = %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	draft := &model.Note{ID: "a.md", Path: "a.md", Title: "A", Date: time.Now().UTC(), Frontmatter: map[string]any{"status": "draft"}}
+	final := &model.Note{ID: "b.md", Path: "b.md", Title: "B", Date: time.Now().UTC(), Frontmatter: map[string]any{"status": "final"}}
+	for _, n := range []*model.Note{draft, final} {
+		if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: n}); err != nil {
+			t.Fatalf("UpsertParsedNote(%s) error = %v", n.ID, err)
+		}
+	}
+
+	notes, err := store.ListNotesByMetadata(ctx, "status", "draft")
+	if err != nil {
+		t.Fatalf("ListNotesByMetadata() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].ID != draft.ID {
+		t.Fatalf("ListNotesByMetadata() = %+v, want only %s", notes, draft.ID)
+	}
+}
@@ -0,0 +1,247 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// TagExpr is a boolean expression over a task's (normalized) tag set,
+// produced by ParseTagExpr from zk-style filter syntax, e.g.
+// "work, -done, project/yapper*". It evaluates in-memory via Eval and
+// translates to a correlated SQL condition via sqlCondition for
+// SQLiteIndexStore.
+type TagExpr interface {
+	// Eval reports whether tags (already normalized via normalizeTags)
+	// satisfies the expression.
+	Eval(tags []string) bool
+	// sqlCondition renders the expression as a boolean SQL condition,
+	// correlated against outerIDExpr (a literal SQL column reference, e.g.
+	// "tasks.task_id") for rows in the tags table with the given
+	// entityKind. It returns the condition text plus its bind arguments, in
+	// the order the "?" placeholders appear.
+	sqlCondition(entityKind, outerIDExpr string) (string, []any)
+}
+
+// TagAnd requires both operands to match.
+type TagAnd struct{ Left, Right TagExpr }
+
+func (e TagAnd) Eval(tags []string) bool { return e.Left.Eval(tags) && e.Right.Eval(tags) }
+
+func (e TagAnd) sqlCondition(entityKind, outerIDExpr string) (string, []any) {
+	lcond, largs := e.Left.sqlCondition(entityKind, outerIDExpr)
+	rcond, rargs := e.Right.sqlCondition(entityKind, outerIDExpr)
+	return "(" + lcond + " AND " + rcond + ")", append(largs, rargs...)
+}
+
+// TagOr requires either operand to match.
+type TagOr struct{ Left, Right TagExpr }
+
+func (e TagOr) Eval(tags []string) bool { return e.Left.Eval(tags) || e.Right.Eval(tags) }
+
+func (e TagOr) sqlCondition(entityKind, outerIDExpr string) (string, []any) {
+	lcond, largs := e.Left.sqlCondition(entityKind, outerIDExpr)
+	rcond, rargs := e.Right.sqlCondition(entityKind, outerIDExpr)
+	return "(" + lcond + " OR " + rcond + ")", append(largs, rargs...)
+}
+
+// TagNot negates its operand.
+type TagNot struct{ Expr TagExpr }
+
+func (e TagNot) Eval(tags []string) bool { return !e.Expr.Eval(tags) }
+
+func (e TagNot) sqlCondition(entityKind, outerIDExpr string) (string, []any) {
+	cond, args := e.Expr.sqlCondition(entityKind, outerIDExpr)
+	return "NOT " + cond, args
+}
+
+// TagLiteral matches a single normalized tag exactly.
+type TagLiteral struct{ Tag string }
+
+func (e TagLiteral) Eval(tags []string) bool {
+	for _, tag := range tags {
+		if tag == e.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (e TagLiteral) sqlCondition(entityKind, outerIDExpr string) (string, []any) {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM tags WHERE entity_kind = ? AND entity_id = %s AND tag = ?)", outerIDExpr),
+		[]any{entityKind, e.Tag}
+}
+
+// TagGlob matches any normalized tag against a glob pattern. A pattern
+// containing "?" or a "[...]" character class is matched via path.Match
+// (Go side) or SQLite's equivalent GLOB operator (SQL side) — the same
+// wildcard syntax, so the two stay in lockstep. Otherwise a trailing "*"
+// matches within one hierarchy segment (it does not cross a "/"), and a
+// trailing "**" matches across segments, the same distinction zk draws
+// between its "*" and "**" globs.
+type TagGlob struct{ Pattern string }
+
+func (e TagGlob) Eval(tags []string) bool {
+	for _, tag := range tags {
+		if e.matches(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e TagGlob) matches(tag string) bool {
+	if isPathGlob(e.Pattern) {
+		ok, err := path.Match(e.Pattern, tag)
+		return err == nil && ok
+	}
+	if prefix, ok := strings.CutSuffix(e.Pattern, "**"); ok {
+		return strings.HasPrefix(tag, prefix)
+	}
+	prefix, ok := strings.CutSuffix(e.Pattern, "*")
+	if !ok {
+		return tag == e.Pattern
+	}
+	rest, ok := strings.CutPrefix(tag, prefix)
+	if !ok {
+		return false
+	}
+	return !strings.Contains(rest, "/")
+}
+
+func (e TagGlob) sqlCondition(entityKind, outerIDExpr string) (string, []any) {
+	if isPathGlob(e.Pattern) {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM tags WHERE entity_kind = ? AND entity_id = %s AND tag GLOB ?)", outerIDExpr),
+			[]any{entityKind, e.Pattern}
+	}
+	if prefix, ok := strings.CutSuffix(e.Pattern, "**"); ok {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM tags WHERE entity_kind = ? AND entity_id = %s AND tag LIKE ?)", outerIDExpr),
+			[]any{entityKind, prefix + "%"}
+	}
+	prefix := strings.TrimSuffix(e.Pattern, "*")
+	return fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM tags WHERE entity_kind = ? AND entity_id = %s AND tag LIKE ? AND tag NOT LIKE ?)", outerIDExpr),
+		[]any{entityKind, prefix + "%", prefix + "%/%"}
+}
+
+// isPathGlob reports whether pattern uses "?" or a "[...]" character class,
+// the glob syntax shared by Go's path.Match and SQLite's GLOB operator, as
+// opposed to the simpler trailing-"*"/"**" zk-style shorthand TagGlob also
+// accepts.
+func isPathGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "?[")
+}
+
+// ParseTagExpr parses a zk-style tag filter expression into a TagExpr. A
+// leading "-" or the (case-insensitive) word "NOT" excludes a tag, a
+// trailing "*"/"**" globs the tag vocabulary (zk-style, scoped to one
+// hierarchy segment unless doubled), a term containing "?" or a "[...]"
+// character class globs via path.Match/SQLite GLOB instead, commas or the
+// word "OR" introduce alternation, and whitespace or the word "AND"
+// combines terms, e.g. "work, -done, project/yapper*" matches tasks tagged
+// "work", OR not tagged "done", OR tagged anywhere under "project/yapper".
+func ParseTagExpr(raw string) (TagExpr, error) {
+	fields := strings.Fields(strings.ReplaceAll(raw, ",", " OR "))
+	if len(fields) == 0 {
+		return nil, errors.New("tag expression cannot be empty")
+	}
+
+	p := &tagExprParser{tokens: fields}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parse tag expression %q: %w", raw, err)
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("parse tag expression %q: unexpected trailing input near %q", raw, p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+type tagExprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *tagExprParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *tagExprParser) parseOr() (TagExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || !strings.EqualFold(tok, "OR") {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = TagOr{Left: left, Right: right}
+	}
+}
+
+func (p *tagExprParser) parseAnd() (TagExpr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || strings.EqualFold(tok, "OR") {
+			return left, nil
+		}
+		if strings.EqualFold(tok, "AND") {
+			p.pos++
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = TagAnd{Left: left, Right: right}
+	}
+}
+
+func (p *tagExprParser) parseTerm() (TagExpr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, errors.New("expression ended unexpectedly")
+	}
+
+	negate := false
+	if strings.EqualFold(tok, "NOT") {
+		p.pos++
+		negate = true
+		tok, ok = p.peek()
+		if !ok {
+			return nil, errors.New("trailing NOT with no tag")
+		}
+	}
+	if after, ok := strings.CutPrefix(tok, "-"); ok {
+		tok = after
+		negate = true
+	}
+	if tok == "" || strings.EqualFold(tok, "AND") || strings.EqualFold(tok, "OR") || strings.EqualFold(tok, "NOT") {
+		return nil, fmt.Errorf("invalid term %q", tok)
+	}
+	p.pos++
+
+	var leaf TagExpr
+	if strings.ContainsAny(tok, "*?[") {
+		leaf = TagGlob{Pattern: normalizeTag(tok)}
+	} else {
+		leaf = TagLiteral{Tag: normalizeTag(tok)}
+	}
+	if negate {
+		return TagNot{Expr: leaf}, nil
+	}
+	return leaf, nil
+}
@@ -0,0 +1,88 @@
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTagExprEval(t *testing.T) {
+	tests := []struct {
+		name  string
+		expr  string
+		tags  []string
+		want  bool
+		isErr bool
+	}{
+		{name: "literal match", expr: "work", tags: []string{"work"}, want: true},
+		{name: "literal case folds", expr: "WORK", tags: []string{"work"}, want: true},
+		{name: "literal no match", expr: "work", tags: []string{"home"}, want: false},
+		{name: "leading dash negates", expr: "-done", tags: []string{"work"}, want: true},
+		{name: "leading dash excludes", expr: "-done", tags: []string{"done"}, want: false},
+		{name: "NOT keyword negates", expr: "NOT done", tags: []string{"work"}, want: true},
+		{name: "comma is OR", expr: "work, home", tags: []string{"home"}, want: true},
+		{name: "OR keyword", expr: "work OR home", tags: []string{"home"}, want: true},
+		{name: "space is AND", expr: "work personal", tags: []string{"work", "personal"}, want: true},
+		{name: "space AND missing tag", expr: "work personal", tags: []string{"work"}, want: false},
+		{name: "AND keyword", expr: "work AND personal", tags: []string{"work", "personal"}, want: true},
+		{
+			name: "mixed grouping",
+			expr: "work personal, -done",
+			tags: []string{"done"},
+			want: false, // "work personal" fails (no work/personal), "-done" fails (has done)
+		},
+		{
+			name: "mixed grouping second branch",
+			expr: "work personal, -done",
+			tags: []string{"errand"},
+			want: true, // "-done" matches since "done" isn't present
+		},
+		{name: "single-star glob within segment", expr: "project*", tags: []string{"project/yapper"}, want: false},
+		{name: "single-star glob matches leaf", expr: "project*", tags: []string{"projectx"}, want: true},
+		{name: "double-star glob crosses segments", expr: "project**", tags: []string{"project/yapper"}, want: true},
+		{name: "question-mark glob matches single char", expr: "wor?", tags: []string{"work"}, want: true},
+		{name: "question-mark glob rejects wrong length", expr: "wor?", tags: []string{"wor"}, want: false},
+		{name: "character class glob matches", expr: "boo[kc]", tags: []string{"book"}, want: true},
+		{name: "character class glob excludes others", expr: "boo[kc]", tags: []string{"boot"}, want: false},
+		{name: "empty expression errors", expr: "", isErr: true},
+		{name: "trailing NOT errors", expr: "work NOT", isErr: true},
+		{name: "trailing AND errors", expr: "work AND", isErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseTagExpr(tt.expr)
+			if tt.isErr {
+				if err == nil {
+					t.Fatalf("ParseTagExpr(%q) expected error, got nil", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTagExpr(%q) error = %v", tt.expr, err)
+			}
+			if got := expr.Eval(tt.tags); got != tt.want {
+				t.Fatalf("ParseTagExpr(%q).Eval(%v) = %v, want %v", tt.expr, tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagGlobSQLCondition(t *testing.T) {
+	single := TagGlob{Pattern: "project*"}
+	cond, args := single.sqlCondition("task", "tasks.task_id")
+	if cond == "" || len(args) != 3 {
+		t.Fatalf("single-star sqlCondition() = %q, args=%v, want a LIKE/NOT LIKE condition with 3 args", cond, args)
+	}
+
+	double := TagGlob{Pattern: "project**"}
+	cond, args = double.sqlCondition("task", "tasks.task_id")
+	if cond == "" || len(args) != 2 {
+		t.Fatalf("double-star sqlCondition() = %q, args=%v, want a single LIKE condition with 2 args", cond, args)
+	}
+
+	class := TagGlob{Pattern: "boo[kc]"}
+	cond, args = class.sqlCondition("task", "tasks.task_id")
+	if !strings.Contains(cond, "GLOB") || len(args) != 2 || args[1] != "boo[kc]" {
+		t.Fatalf("character-class sqlCondition() = %q, args=%v, want a GLOB condition passing the pattern through verbatim", cond, args)
+	}
+}
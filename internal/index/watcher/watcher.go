@@ -0,0 +1,269 @@
+// Package watcher turns a one-shot VaultIndexManager reindex into a live
+// view of a vault: it watches the vault's root directory with fsnotify and
+// applies debounced create/modify/remove events to the index as they
+// settle.
+//
+// This lives alongside internal/index rather than inside internal/vault
+// (where the originating request placed it) because it needs both
+// vault.FileSystemVault and index.VaultIndexManager in scope at once, and
+// internal/index already imports internal/vault; housing it in
+// internal/vault would create an import cycle.
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+// debounceWindow coalesces bursts of events for the same path, such as an
+// editor that writes a swap file and then renames it over the original,
+// into a single reindex.
+const debounceWindow = 200 * time.Millisecond
+
+// ChangeKind describes what kind of filesystem event produced an IndexEvent.
+type ChangeKind string
+
+const (
+	ChangeCreated  ChangeKind = "created"
+	ChangeModified ChangeKind = "modified"
+	ChangeRemoved  ChangeKind = "removed"
+)
+
+// IndexEvent reports a settled, applied change to the index, for callers
+// (an LSP server, a TUI) that want to react to a live vault instead of
+// polling it.
+type IndexEvent struct {
+	Path string
+	Kind ChangeKind
+	// Err is non-nil if applying the change to the index failed. Path and
+	// Kind are still populated in that case.
+	Err error
+}
+
+// Watcher observes a vault's root directory for .md file changes and
+// applies them to manager's IndexStore as they settle.
+type Watcher struct {
+	root    string
+	manager *index.VaultIndexManager
+	fsw     *fsnotify.Watcher
+	events  chan IndexEvent
+
+	mu      sync.Mutex
+	timers  map[string]*time.Timer
+	pending map[string]ChangeKind
+	wg      sync.WaitGroup
+	closing bool
+}
+
+// New creates a Watcher rooted at v.Root() that applies changes through
+// manager. The returned Watcher does not observe events until Run is called.
+func New(v *vault.FileSystemVault, manager *index.VaultIndexManager) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create watcher: %w", err)
+	}
+
+	w := &Watcher{
+		root:    v.Root(),
+		manager: manager,
+		fsw:     fsw,
+		events:  make(chan IndexEvent, 32),
+		timers:  make(map[string]*time.Timer),
+		pending: make(map[string]ChangeKind),
+	}
+
+	if err := w.addRecursive(w.root); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("watch vault root: %w", err)
+	}
+
+	return w, nil
+}
+
+// Events returns the channel of applied IndexEvents. It is closed once Run
+// returns.
+func (w *Watcher) Events() <-chan IndexEvent {
+	return w.events
+}
+
+// Run processes filesystem events and applies settled changes to the index
+// until ctx is canceled. It blocks the calling goroutine, and closes Events
+// and releases the underlying fsnotify watcher before returning.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer w.drain()
+	defer w.fsw.Close()
+	defer close(w.events)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(ctx, event)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			w.emit(ctx, IndexEvent{Err: fmt.Errorf("watcher: %w", err)})
+		}
+	}
+}
+
+// addRecursive registers dir and every subdirectory beneath it with
+// fsnotify. fsnotify only watches the directories it's told about, so new
+// directories created later are picked up as they arrive in handleEvent.
+func (w *Watcher) addRecursive(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return w.fsw.Add(path)
+		}
+		return nil
+	})
+}
+
+func (w *Watcher) relPath(fullPath string) string {
+	rel, err := filepath.Rel(w.root, fullPath)
+	if err != nil {
+		return fullPath
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (w *Watcher) handleEvent(ctx context.Context, event fsnotify.Event) {
+	if isTransient(event.Name) {
+		return
+	}
+
+	if !strings.EqualFold(filepath.Ext(event.Name), ".md") {
+		if event.Op&fsnotify.Create != 0 {
+			_ = w.fsw.Add(event.Name) // track a newly created subdirectory; errors here mean it wasn't one
+		}
+		return
+	}
+
+	kind := ChangeModified
+	switch {
+	case event.Op&fsnotify.Create != 0:
+		kind = ChangeCreated
+	case event.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		kind = ChangeRemoved
+	}
+
+	w.debounce(ctx, event.Name, kind)
+}
+
+// debounce resets any pending timer for fullPath and schedules apply to run
+// after debounceWindow. wg tracks every scheduled-but-not-yet-run timer so
+// Run can wait for in-flight applies to finish before closing Events.
+//
+// A burst of events for the same path (e.g. a new file's CREATE followed by
+// one or more WRITEs before the window elapses) is coalesced into a single
+// apply, using mergeChangeKind to combine each new event's kind with
+// whatever's already pending rather than simply overwriting it, so a
+// trailing WRITE can't hide the fact that the file was just created.
+func (w *Watcher) debounce(ctx context.Context, fullPath string, kind ChangeKind) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closing {
+		return
+	}
+
+	kind = mergeChangeKind(w.pending[fullPath], kind)
+	w.pending[fullPath] = kind
+
+	if t, ok := w.timers[fullPath]; ok && t.Stop() {
+		w.wg.Done()
+	}
+
+	w.wg.Add(1)
+	w.timers[fullPath] = time.AfterFunc(debounceWindow, func() {
+		defer w.wg.Done()
+		w.mu.Lock()
+		if w.closing {
+			w.mu.Unlock()
+			return
+		}
+		delete(w.timers, fullPath)
+		finalKind := w.pending[fullPath]
+		delete(w.pending, fullPath)
+		w.mu.Unlock()
+		w.apply(ctx, fullPath, finalKind)
+	})
+}
+
+// mergeChangeKind combines a newly observed event kind with whatever kind is
+// already pending for the same path in the current debounce burst. A
+// Removed event always wins, since the file is gone regardless of what
+// preceded it; otherwise a pending Created is kept, since a WRITE
+// immediately following a CREATE is still part of the file coming into
+// existence. An empty prev (no event pending yet) just takes next as-is.
+func mergeChangeKind(prev, next ChangeKind) ChangeKind {
+	if prev == "" {
+		return next
+	}
+	if prev == ChangeRemoved || next == ChangeRemoved {
+		return ChangeRemoved
+	}
+	if prev == ChangeCreated {
+		return ChangeCreated
+	}
+	return next
+}
+
+func (w *Watcher) apply(ctx context.Context, fullPath string, kind ChangeKind) {
+	path := w.relPath(fullPath)
+	var err error
+	if kind == ChangeRemoved {
+		err = w.manager.RemoveNote(ctx, path)
+	} else {
+		err = w.manager.ReindexNote(ctx, path)
+	}
+	w.emit(ctx, IndexEvent{Path: path, Kind: kind, Err: err})
+}
+
+func (w *Watcher) emit(ctx context.Context, evt IndexEvent) {
+	select {
+	case w.events <- evt:
+	case <-ctx.Done():
+	}
+}
+
+// drain stops every pending timer and waits for any in-flight apply calls to
+// finish, so Run can safely close Events afterward without a send racing
+// the close.
+func (w *Watcher) drain() {
+	w.mu.Lock()
+	w.closing = true
+	for path, t := range w.timers {
+		if t.Stop() {
+			w.wg.Done()
+		}
+		delete(w.timers, path)
+		delete(w.pending, path)
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+}
+
+// isTransient reports whether path looks like an editor swap or backup
+// file rather than a real content change.
+func isTransient(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".swp") || strings.HasSuffix(base, ".swx") || strings.HasSuffix(base, "~")
+}
@@ -0,0 +1,106 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/parser"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+func waitForEvent(t *testing.T, events <-chan IndexEvent, wantKind ChangeKind) IndexEvent {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case evt := <-events:
+			if evt.Kind == wantKind {
+				return evt
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for a %q event", wantKind)
+		}
+	}
+}
+
+func newTestWatcher(t *testing.T, root string) (*Watcher, *index.InMemoryIndexStore, context.CancelFunc) {
+	t.Helper()
+	v, err := vault.NewFileSystemVault(root)
+	if err != nil {
+		t.Fatalf("NewFileSystemVault: %v", err)
+	}
+	store := index.NewInMemoryIndexStore()
+	manager, err := index.NewVaultIndexManager(v, parser.NewRegexNoteParser(), store)
+	if err != nil {
+		t.Fatalf("NewVaultIndexManager: %v", err)
+	}
+	if err := manager.FullReindex(context.Background()); err != nil {
+		t.Fatalf("FullReindex: %v", err)
+	}
+
+	w, err := New(v, manager)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return w, store, cancel
+}
+
+func TestWatcher_IndexesCreatedNote(t *testing.T) {
+	root := t.TempDir()
+	w, store, _ := newTestWatcher(t, root)
+
+	notePath := filepath.Join(root, "note.md")
+	if err := os.WriteFile(notePath, []byte("# Hello\n\nbody text\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	waitForEvent(t, w.Events(), ChangeCreated)
+
+	notes, err := store.ListNotes(context.Background(), index.NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes: %v", err)
+	}
+	if len(notes) != 1 || notes[0].Title != "Hello" {
+		t.Fatalf("expected indexed note titled Hello, got %+v", notes)
+	}
+}
+
+func TestWatcher_RemovesDeletedNote(t *testing.T) {
+	root := t.TempDir()
+	w, store, _ := newTestWatcher(t, root)
+
+	notePath := filepath.Join(root, "note.md")
+	if err := os.WriteFile(notePath, []byte("# Hello\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	waitForEvent(t, w.Events(), ChangeCreated)
+
+	if err := os.Remove(notePath); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	waitForEvent(t, w.Events(), ChangeRemoved)
+
+	notes, err := store.ListNotes(context.Background(), index.NoteFilter{})
+	if err != nil {
+		t.Fatalf("ListNotes: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Fatalf("expected note to be removed from the index, got %+v", notes)
+	}
+}
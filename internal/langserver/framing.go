@@ -0,0 +1,85 @@
+package langserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// request is the minimal JSON-RPC 2.0 envelope this server accepts. Requests
+// carry a non-nil ID; notifications (didChangeWatchedFiles) omit it.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is the minimal JSON-RPC 2.0 envelope this server emits.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// readMessage parses a single Content-Length framed LSP message, per the
+// base protocol's header/body framing (distinct from the newline-delimited
+// framing internal/server's JSON-RPC daemon uses).
+func readMessage(r *bufio.Reader) (request, error) {
+	var contentLength int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return request{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			value := strings.TrimSpace(line[len("content-length:"):])
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return request{}, fmt.Errorf("malformed Content-Length header %q: %w", value, err)
+			}
+			contentLength = n
+		}
+	}
+	if contentLength <= 0 {
+		return request{}, fmt.Errorf("missing or invalid Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return request{}, err
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return request{}, fmt.Errorf("malformed LSP message: %w", err)
+	}
+	return req, nil
+}
+
+// writeMessage serializes resp and frames it with a Content-Length header.
+func writeMessage(w io.Writer, resp response) error {
+	resp.JSONRPC = "2.0"
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(payload)); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
@@ -0,0 +1,593 @@
+package langserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// wikiLinkPattern matches [[note title or path]] references. taskRefPattern
+// matches the bracketed [T-xxxx] task references the parser recognizes (see
+// RegexNoteParser.taskIDPattern). taskRefPrefixPattern matches an
+// in-progress "[" or "[T-..." the cursor sits at the end of, for completion.
+var (
+	wikiLinkPattern      = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+	taskRefPattern       = regexp.MustCompile(`\[(T-[A-Za-z0-9_-]+)\]`)
+	taskRefPrefixPattern = regexp.MustCompile(`^\[(?:T-[A-Za-z0-9_-]*)?$`)
+	slugNonAlphanumeric  = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// token is whichever reference, if any, sits under the cursor.
+type token struct {
+	wikiTarget string
+	taskID     model.TaskID
+}
+
+// tokenAt scans line for a wiki-link or task reference whose match span
+// contains the character offset col.
+func tokenAt(line string, col int) (token, bool) {
+	for _, m := range wikiLinkPattern.FindAllStringSubmatchIndex(line, -1) {
+		if col >= m[0] && col < m[1] {
+			return token{wikiTarget: line[m[2]:m[3]]}, true
+		}
+	}
+	for _, m := range taskRefPattern.FindAllStringSubmatchIndex(line, -1) {
+		if col >= m[0] && col < m[1] {
+			return token{taskID: model.TaskID(line[m[2]:m[3]])}, true
+		}
+	}
+	return token{}, false
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("parse URI %q: %w", uri, err)
+	}
+	if u.Scheme != "" && u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme %q", u.Scheme)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
+
+func lineAt(path string, lineNum int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read %q: %w", path, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if lineNum < 0 || lineNum >= len(lines) {
+		return "", fmt.Errorf("line %d out of range in %q", lineNum, path)
+	}
+	return lines[lineNum], nil
+}
+
+// notePathFor resolves a note's vault-relative path given the vault it
+// belongs to and its NoteID.
+func (s *Server) notePathFor(ctx context.Context, vaultName string, noteID model.NoteID) (string, error) {
+	_, store, ok := s.registry.Vault(vaultName)
+	if !ok {
+		return "", fmt.Errorf("vault %q not registered", vaultName)
+	}
+	notes, err := store.ListNotes(ctx, index.NoteFilter{})
+	if err != nil {
+		return "", fmt.Errorf("list notes in vault %q: %w", vaultName, err)
+	}
+	for _, n := range notes {
+		if n.ID == noteID {
+			return n.Path, nil
+		}
+	}
+	return "", fmt.Errorf("note %q not found in vault %q", noteID, vaultName)
+}
+
+func (s *Server) noteLocation(vaultName, notePath string, pos Position) (Location, bool) {
+	root, ok := s.registry.Root(vaultName)
+	if !ok {
+		return Location{}, false
+	}
+	return Location{
+		URI:   pathToURI(filepath.Join(root, filepath.FromSlash(notePath))),
+		Range: Range{Start: pos, End: pos},
+	}, true
+}
+
+// definition implements textDocument/definition for wiki-style [[note]]
+// links and bracketed [T-xxxx] task references.
+func (s *Server) definition(ctx context.Context, p TextDocumentPositionParams) ([]Location, error) {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	line, err := lineAt(path, p.Position.Line)
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := tokenAt(line, p.Position.Character)
+	if !ok {
+		return nil, nil
+	}
+
+	if tok.wikiTarget != "" {
+		return s.locationsForNoteTitle(ctx, tok.wikiTarget)
+	}
+	return s.locationsForTask(ctx, tok.taskID)
+}
+
+func (s *Server) locationsForNoteTitle(ctx context.Context, target string) ([]Location, error) {
+	notes, err := s.registry.ListNotes(ctx, index.NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var locs []Location
+	for _, n := range notes {
+		stem := strings.TrimSuffix(filepath.Base(n.Note.Path), filepath.Ext(n.Note.Path))
+		if !strings.EqualFold(n.Note.Title, target) && !strings.EqualFold(stem, target) {
+			continue
+		}
+		if loc, ok := s.noteLocation(n.VaultName, n.Note.Path, Position{}); ok {
+			locs = append(locs, loc)
+		}
+	}
+	return locs, nil
+}
+
+func (s *Server) locationsForTask(ctx context.Context, id model.TaskID) ([]Location, error) {
+	if id == "" {
+		return nil, nil
+	}
+	tasks, err := s.registry.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var locs []Location
+	for _, t := range tasks {
+		if t.Task.ID != id {
+			continue
+		}
+		notePath, err := s.notePathFor(ctx, t.VaultName, t.Task.NoteID)
+		if err != nil {
+			continue
+		}
+		if loc, ok := s.noteLocation(t.VaultName, notePath, Position{Line: t.Task.Line}); ok {
+			locs = append(locs, loc)
+		}
+	}
+	return locs, nil
+}
+
+// references implements textDocument/references for a task reference under
+// the cursor, backed by Registry.GetMentionsForTask.
+func (s *Server) references(ctx context.Context, p TextDocumentPositionParams) ([]Location, error) {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	line, err := lineAt(path, p.Position.Line)
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := tokenAt(line, p.Position.Character)
+	if !ok || tok.taskID == "" {
+		return nil, nil
+	}
+
+	mentions, err := s.registry.GetMentionsForTask(ctx, tok.taskID)
+	if err != nil {
+		return nil, err
+	}
+	var locs []Location
+	for _, tm := range mentions {
+		notePath, err := s.notePathFor(ctx, tm.VaultName, tm.Mention.NoteID)
+		if err != nil {
+			continue
+		}
+		if loc, ok := s.noteLocation(tm.VaultName, notePath, Position{Line: tm.Mention.Line}); ok {
+			locs = append(locs, loc)
+		}
+	}
+	return locs, nil
+}
+
+// completion implements textDocument/completion for "#tag" prefixes (backed
+// by ListTags) and "[[" note-title prefixes (backed by ListNotes).
+func (s *Server) completion(ctx context.Context, p TextDocumentPositionParams) ([]CompletionItem, error) {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	line, err := lineAt(path, p.Position.Line)
+	if err != nil {
+		return nil, err
+	}
+	col := p.Position.Character
+	if col > len(line) {
+		col = len(line)
+	}
+	prefix := line[:col]
+
+	switch {
+	case strings.HasSuffix(prefix, "[["):
+		return s.noteCompletions(ctx)
+	case taskRefPrefixPattern.MatchString(lastWord(prefix)):
+		return s.taskCompletions(ctx)
+	case strings.HasPrefix(lastWord(prefix), "#"):
+		return s.tagCompletions(ctx)
+	default:
+		return nil, nil
+	}
+}
+
+// lastWord returns the final whitespace-delimited run of s, i.e. the
+// in-progress word the cursor sits at the end of.
+func lastWord(s string) string {
+	idx := strings.LastIndexAny(s, " \t")
+	return s[idx+1:]
+}
+
+func (s *Server) tagCompletions(ctx context.Context) ([]CompletionItem, error) {
+	tags, err := s.registry.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]CompletionItem, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, CompletionItem{Label: tag, Kind: CompletionKindTag})
+	}
+	return items, nil
+}
+
+// taskCompletions backs "[T-" prefix completion with every known task ID.
+func (s *Server) taskCompletions(ctx context.Context) ([]CompletionItem, error) {
+	tasks, err := s.registry.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]CompletionItem, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, CompletionItem{
+			Label:  string(t.Task.ID),
+			Kind:   CompletionKindTask,
+			Detail: t.Task.Title,
+		})
+	}
+	return items, nil
+}
+
+func (s *Server) noteCompletions(ctx context.Context) ([]CompletionItem, error) {
+	notes, err := s.registry.ListNotes(ctx, index.NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]CompletionItem, 0, len(notes))
+	for _, n := range notes {
+		items = append(items, CompletionItem{
+			Label:  n.Note.Title,
+			Kind:   CompletionKindNote,
+			Detail: fmt.Sprintf("%s/%s", n.VaultName, n.Note.Path),
+		})
+	}
+	return items, nil
+}
+
+// workspaceSymbol implements workspace/symbol over both tasks and notes,
+// filtering on a case-insensitive substring match against the query.
+func (s *Server) workspaceSymbol(ctx context.Context, p WorkspaceSymbolParams) ([]SymbolInformation, error) {
+	query := strings.ToLower(p.Query)
+	var symbols []SymbolInformation
+
+	tasks, err := s.registry.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tasks {
+		if query != "" && !strings.Contains(strings.ToLower(t.Task.Title), query) {
+			continue
+		}
+		notePath, err := s.notePathFor(ctx, t.VaultName, t.Task.NoteID)
+		if err != nil {
+			continue
+		}
+		loc, ok := s.noteLocation(t.VaultName, notePath, Position{Line: t.Task.Line})
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, SymbolInformation{Name: t.Task.Title, Kind: SymbolKindTask, Location: loc})
+	}
+
+	notes, err := s.registry.ListNotes(ctx, index.NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range notes {
+		if query != "" && !strings.Contains(strings.ToLower(n.Note.Title), query) {
+			continue
+		}
+		loc, ok := s.noteLocation(n.VaultName, n.Note.Path, Position{})
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, SymbolInformation{Name: n.Note.Title, Kind: SymbolKindFile, Location: loc})
+	}
+
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Name < symbols[j].Name })
+	return symbols, nil
+}
+
+// codeLens implements textDocument/codeLens, annotating each task declared
+// in the document with its mention count.
+func (s *Server) codeLens(ctx context.Context, p CodeLensParams) ([]CodeLens, error) {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	vaultName, relPath, ok := s.registry.VaultForPath(path)
+	if !ok {
+		return nil, fmt.Errorf("no registered vault owns %q", path)
+	}
+	_, store, ok := s.registry.Vault(vaultName)
+	if !ok {
+		return nil, fmt.Errorf("vault %q not registered", vaultName)
+	}
+
+	notes, err := store.ListNotes(ctx, index.NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var noteID model.NoteID
+	found := false
+	for _, n := range notes {
+		if n.Path == relPath {
+			noteID = n.ID
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var lenses []CodeLens
+	for _, t := range tasks {
+		if t.NoteID != noteID {
+			continue
+		}
+		mentions, err := store.GetMentionsForTask(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		pos := Position{Line: t.Line}
+		lenses = append(lenses, CodeLens{
+			Range:   Range{Start: pos, End: pos},
+			Command: &Command{Title: fmt.Sprintf("%d references", len(mentions))},
+		})
+	}
+	return lenses, nil
+}
+
+// didChangeWatchedFiles drives the same upsert/remove paths a live
+// index/watcher.Watcher would, so editors that already track filesystem
+// changes themselves (instead of running a separate watcher process) can
+// keep the index current by forwarding them here.
+func (s *Server) didChangeWatchedFiles(ctx context.Context, p DidChangeWatchedFilesParams) error {
+	for _, change := range p.Changes {
+		path, err := uriToPath(change.URI)
+		if err != nil {
+			return err
+		}
+		vaultName, relPath, ok := s.registry.VaultForPath(path)
+		if !ok {
+			continue
+		}
+		manager, _, ok := s.registry.Vault(vaultName)
+		if !ok {
+			continue
+		}
+
+		if change.Type == FileChangeDeleted {
+			if err := manager.RemoveNote(ctx, relPath); err != nil {
+				return fmt.Errorf("remove note %q in vault %q: %w", relPath, vaultName, err)
+			}
+			continue
+		}
+		if err := manager.ReindexNote(ctx, relPath); err != nil {
+			return fmt.Errorf("reindex note %q in vault %q: %w", relPath, vaultName, err)
+		}
+	}
+	return nil
+}
+
+// didSave refreshes the saved document's indexed representation, the same
+// way didChangeWatchedFiles does for externally-observed changes, for
+// editors that notify on save rather than (or in addition to) filesystem
+// watches.
+func (s *Server) didSave(ctx context.Context, p DidSaveTextDocumentParams) error {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	vaultName, relPath, ok := s.registry.VaultForPath(path)
+	if !ok {
+		return nil
+	}
+	manager, _, ok := s.registry.Vault(vaultName)
+	if !ok {
+		return nil
+	}
+	return manager.ReindexNote(ctx, relPath)
+}
+
+// hover implements textDocument/hover for a [T-xxxx] task reference under the
+// cursor, showing its title, status, and most recent mentions.
+func (s *Server) hover(ctx context.Context, p TextDocumentPositionParams) (*Hover, error) {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	line, err := lineAt(path, p.Position.Line)
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := tokenAt(line, p.Position.Character)
+	if !ok || tok.taskID == "" {
+		return nil, nil
+	}
+
+	tasks, err := s.registry.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var task *model.Task
+	for i := range tasks {
+		if tasks[i].Task.ID == tok.taskID {
+			t := tasks[i].Task
+			task = &t
+			break
+		}
+	}
+	if task == nil {
+		return nil, nil
+	}
+
+	mentions, err := s.registry.GetMentionsForTask(ctx, tok.taskID)
+	if err != nil {
+		return nil, err
+	}
+	const maxRecentMentions = 3
+	if len(mentions) > maxRecentMentions {
+		mentions = mentions[len(mentions)-maxRecentMentions:]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**\n\nStatus: %s", task.Title, task.Status)
+	if len(mentions) > 0 {
+		b.WriteString("\n\nRecent mentions:\n")
+		for _, m := range mentions {
+			fmt.Fprintf(&b, "- %s\n", m.Mention.Context)
+		}
+	}
+
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}}, nil
+}
+
+// executeCommand implements workspace/executeCommand, dispatching to the
+// single command this server recognizes.
+func (s *Server) executeCommand(ctx context.Context, p ExecuteCommandParams) (*NewNoteResult, error) {
+	switch p.Command {
+	case "yapper.newNote":
+		if len(p.Arguments) == 0 {
+			return nil, fmt.Errorf("yapper.newNote: missing arguments")
+		}
+		var args NewNoteParams
+		if err := json.Unmarshal(p.Arguments[0], &args); err != nil {
+			return nil, fmt.Errorf("yapper.newNote: decode arguments: %w", err)
+		}
+		return s.newNote(ctx, args)
+	default:
+		return nil, fmt.Errorf("unsupported command %q", p.Command)
+	}
+}
+
+// newNote creates a dated, templated note in the named vault and, if
+// InsertLinkAt is set, inserts a [[wiki link]] to it at that cursor
+// position in the already-open document.
+func (s *Server) newNote(ctx context.Context, args NewNoteParams) (*NewNoteResult, error) {
+	manager, _, ok := s.registry.Vault(args.VaultName)
+	if !ok {
+		return nil, fmt.Errorf("vault %q not registered", args.VaultName)
+	}
+	root, ok := s.registry.Root(args.VaultName)
+	if !ok {
+		return nil, fmt.Errorf("vault %q not registered", args.VaultName)
+	}
+
+	title := strings.TrimSpace(args.Title)
+	if title == "" {
+		title = "Untitled"
+	}
+	date := time.Now().UTC().Format("2006-01-02")
+	relPath := fmt.Sprintf("%s-%s.md", date, slugify(title))
+	content := fmt.Sprintf("---\ndate: %s\n---\n# %s\n\n", date, title)
+
+	fullPath := filepath.Join(root, filepath.FromSlash(relPath))
+	if err := os.WriteFile(fullPath, []byte(content), 0o644); err != nil {
+		return nil, fmt.Errorf("write new note %q: %w", relPath, err)
+	}
+	if err := manager.ReindexNote(ctx, relPath); err != nil {
+		return nil, fmt.Errorf("reindex new note %q: %w", relPath, err)
+	}
+
+	if args.InsertLinkAt != nil {
+		if err := s.insertLink(ctx, *args.InsertLinkAt, title); err != nil {
+			return nil, err
+		}
+	}
+
+	return &NewNoteResult{URI: pathToURI(fullPath), Path: relPath}, nil
+}
+
+// insertLink splices a "[[title]]" wiki link into the document at p on disk,
+// then reindexes that document so the new link is reflected immediately.
+func (s *Server) insertLink(ctx context.Context, p TextDocumentPositionParams, title string) error {
+	path, err := uriToPath(p.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %q: %w", path, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if p.Position.Line < 0 || p.Position.Line >= len(lines) {
+		return fmt.Errorf("line %d out of range in %q", p.Position.Line, path)
+	}
+	line := lines[p.Position.Line]
+	col := p.Position.Character
+	if col < 0 || col > len(line) {
+		col = len(line)
+	}
+	lines[p.Position.Line] = line[:col] + "[[" + title + "]]" + line[col:]
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+
+	vaultName, relPath, ok := s.registry.VaultForPath(path)
+	if !ok {
+		return nil
+	}
+	manager, _, ok := s.registry.Vault(vaultName)
+	if !ok {
+		return nil
+	}
+	return manager.ReindexNote(ctx, relPath)
+}
+
+// slugify lowercases title and collapses runs of non-alphanumeric
+// characters into a single "-", for use in a generated note's filename.
+func slugify(title string) string {
+	slug := strings.Trim(slugNonAlphanumeric.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		return "note"
+	}
+	return slug
+}
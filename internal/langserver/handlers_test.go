@@ -0,0 +1,244 @@
+package langserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/parser"
+	"github.com/weakphish/yapper/internal/vault"
+)
+
+func newTestServer(t *testing.T, files map[string]string) (*Server, string) {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	v, err := vault.NewNamedFileSystemVault("test", root)
+	if err != nil {
+		t.Fatalf("NewNamedFileSystemVault: %v", err)
+	}
+	store := index.NewInMemoryIndexStore()
+	manager, err := index.NewVaultIndexManager(v, parser.NewRegexNoteParser(), store)
+	if err != nil {
+		t.Fatalf("NewVaultIndexManager: %v", err)
+	}
+	if err := manager.FullReindex(context.Background()); err != nil {
+		t.Fatalf("FullReindex: %v", err)
+	}
+
+	registry := index.NewRegistry()
+	if err := registry.Register("test", v, manager, store); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	return NewServer(registry), root
+}
+
+func TestServer_DefinitionResolvesWikiLink(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"a.md": "# Note A\n\n[[Note B]]\n",
+		"b.md": "# Note B\n\nbody\n",
+	})
+
+	locs, err := s.definition(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "a.md"))},
+		Position:     Position{Line: 2, Character: 3},
+	})
+	if err != nil {
+		t.Fatalf("definition: %v", err)
+	}
+	if len(locs) != 1 {
+		t.Fatalf("expected 1 location, got %d: %+v", len(locs), locs)
+	}
+	wantURI := pathToURI(filepath.Join(root, "b.md"))
+	if locs[0].URI != wantURI {
+		t.Fatalf("URI = %q, want %q", locs[0].URI, wantURI)
+	}
+}
+
+func TestServer_ReferencesFindsTaskMentions(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Tasks\n\n- [ ] Ship it [T-1]\n\n## Log\n\n- Checked on [T-1] today\n",
+	})
+
+	locs, err := s.references(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 4, Character: 16},
+	})
+	if err != nil {
+		t.Fatalf("references: %v", err)
+	}
+	if len(locs) != 1 {
+		t.Fatalf("expected 1 mention location, got %d: %+v", len(locs), locs)
+	}
+	wantURI := pathToURI(filepath.Join(root, "tasks.md"))
+	if locs[0].URI != wantURI {
+		t.Fatalf("URI = %q, want %q", locs[0].URI, wantURI)
+	}
+}
+
+func TestServer_CompletionSuggestsTagsAndNoteTitles(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Log\n\n- Paid the #rent bill\n\n[[\n",
+		"other.md": "# Other Note\n\nbody\n",
+	})
+
+	tagItems, err := s.completion(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 4, Character: 12},
+	})
+	if err != nil {
+		t.Fatalf("completion (tag prefix): %v", err)
+	}
+	if len(tagItems) != 1 || tagItems[0].Label != "rent" {
+		t.Fatalf("expected the rent tag, got %+v", tagItems)
+	}
+
+	noteItems, err := s.completion(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 6, Character: 2},
+	})
+	if err != nil {
+		t.Fatalf("completion (wiki-link prefix): %v", err)
+	}
+	if len(noteItems) != 2 {
+		t.Fatalf("expected both notes as completion candidates, got %+v", noteItems)
+	}
+}
+
+func TestServer_WorkspaceSymbolFiltersByQuery(t *testing.T) {
+	s, _ := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Tasks\n\n- [ ] Renew passport [T-1]\n- [ ] Buy milk [T-2]\n",
+	})
+
+	symbols, err := s.workspaceSymbol(context.Background(), WorkspaceSymbolParams{Query: "passport"})
+	if err != nil {
+		t.Fatalf("workspaceSymbol: %v", err)
+	}
+	if len(symbols) != 1 || symbols[0].Name != "Renew passport" {
+		t.Fatalf("expected only the passport task, got %+v", symbols)
+	}
+}
+
+func TestServer_CompletionSuggestsTaskIDs(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Tasks\n\n- [ ] Ship it [T-1]\n\nSee [T-\n",
+	})
+
+	items, err := s.completion(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 6, Character: 7},
+	})
+	if err != nil {
+		t.Fatalf("completion (task-ID prefix): %v", err)
+	}
+	if len(items) != 1 || items[0].Label != "T-1" || items[0].Kind != CompletionKindTask {
+		t.Fatalf("expected the T-1 task completion, got %+v", items)
+	}
+}
+
+func TestServer_HoverShowsTaskStatusAndMentions(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Tasks\n\n- [ ] Ship it [T-1]\n\n## Log\n\n- Checked on [T-1] today\n",
+	})
+
+	hover, err := s.hover(context.Background(), TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 4, Character: 16},
+	})
+	if err != nil {
+		t.Fatalf("hover: %v", err)
+	}
+	if hover == nil {
+		t.Fatal("hover: expected non-nil result")
+	}
+	if !strings.Contains(hover.Contents.Value, "Ship it") || !strings.Contains(hover.Contents.Value, "todo") {
+		t.Fatalf("hover contents = %q, want title and status", hover.Contents.Value)
+	}
+}
+
+func TestServer_DidSaveReindexesNote(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"a.md": "# Note A\n\nbody\n",
+	})
+	path := filepath.Join(root, "a.md")
+	// Tags are only extracted from the Tasks and Log sections (see
+	// RegexNoteParser.Parse), so the edit has to land a tag there for
+	// reindexing to actually pick it up.
+	if err := os.WriteFile(path, []byte("# Note A\n\n## Log\n\n- Paid the #fresh bill\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := s.didSave(context.Background(), DidSaveTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(path)},
+	}); err != nil {
+		t.Fatalf("didSave: %v", err)
+	}
+
+	tags, err := s.registry.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	found := false
+	for _, tag := range tags {
+		if tag == "fresh" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected didSave to reindex the edited note and pick up #fresh, tags = %v", tags)
+	}
+}
+
+func TestServer_ExecuteCommandNewNoteCreatesAndLinksNote(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"journal.md": "# Journal\n\nToday: \n",
+	})
+
+	raw, err := json.Marshal(NewNoteParams{
+		VaultName: "test",
+		Title:     "Grocery List",
+		InsertLinkAt: &TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "journal.md"))},
+			Position:     Position{Line: 2, Character: 7},
+		},
+	})
+	if err != nil {
+		t.Fatalf("marshal NewNoteParams: %v", err)
+	}
+
+	result, err := s.executeCommand(context.Background(), ExecuteCommandParams{
+		Command:   "yapper.newNote",
+		Arguments: []json.RawMessage{raw},
+	})
+	if err != nil {
+		t.Fatalf("executeCommand: %v", err)
+	}
+	if result == nil || !strings.Contains(result.Path, "grocery-list") {
+		t.Fatalf("expected a new note slugged from the title, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(root, result.Path)); err != nil {
+		t.Fatalf("expected new note to exist on disk: %v", err)
+	}
+
+	journal, err := os.ReadFile(filepath.Join(root, "journal.md"))
+	if err != nil {
+		t.Fatalf("read journal.md: %v", err)
+	}
+	if !strings.Contains(string(journal), "[[Grocery List]]") {
+		t.Fatalf("expected journal.md to contain the inserted link, got %q", journal)
+	}
+}
@@ -0,0 +1,155 @@
+package langserver
+
+import "encoding/json"
+
+// This file defines the small subset of the Language Server Protocol's wire
+// types this server needs. It intentionally does not attempt to model the
+// full spec.
+
+// Position is a zero-based line/character offset within a text document.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range spans from Start up to, but not including, End.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Location points at a Range within a file, identified by a file:// URI.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// TextDocumentIdentifier identifies an open or on-disk text document by URI.
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// TextDocumentPositionParams is the shared params shape for definition,
+// references, and completion requests.
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// CompletionItemKind values used by this server. The LSP enum has many more
+// kinds; these are the ones relevant to tag, note-title, and task-ID
+// completions.
+const (
+	CompletionKindTag  = 14 // Keyword
+	CompletionKindNote = 17 // File
+	CompletionKindTask = 18 // Reference
+)
+
+// CompletionItem is a single completion candidate.
+type CompletionItem struct {
+	Label  string `json:"label"`
+	Kind   int    `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// WorkspaceSymbolParams carries the query string for workspace/symbol.
+type WorkspaceSymbolParams struct {
+	Query string `json:"query"`
+}
+
+// SymbolKind values used by workspace/symbol results. The LSP spec has no
+// dedicated kind for a task, so SymbolKindTask reuses "Property" (7), the
+// closest stand-in for a named, status-bearing item.
+const (
+	SymbolKindFile = 1
+	SymbolKindTask = 7
+)
+
+// SymbolInformation is a single workspace/symbol result.
+type SymbolInformation struct {
+	Name     string   `json:"name"`
+	Kind     int      `json:"kind"`
+	Location Location `json:"location"`
+}
+
+// Command describes a client-side action a CodeLens can trigger. Lenses
+// produced by this server are informational only, so Command is never set.
+type Command struct {
+	Title   string `json:"title"`
+	Command string `json:"command,omitempty"`
+}
+
+// CodeLens annotates a Range with a Command, or in this server's case, just
+// an informational title (a mention count).
+type CodeLens struct {
+	Range   Range    `json:"range"`
+	Command *Command `json:"command,omitempty"`
+}
+
+// CodeLensParams carries the document to compute lenses for.
+type CodeLensParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// FileChangeType mirrors the LSP FileChangeType enum used by
+// didChangeWatchedFiles notifications.
+type FileChangeType int
+
+const (
+	FileChangeCreated FileChangeType = 1
+	FileChangeChanged FileChangeType = 2
+	FileChangeDeleted FileChangeType = 3
+)
+
+// FileEvent reports one watched file's change.
+type FileEvent struct {
+	URI  string         `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// DidChangeWatchedFilesParams carries the batch of file changes a client
+// observed and forwarded to the server.
+type DidChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+// DidSaveTextDocumentParams carries the identity of a document the client
+// just saved to disk.
+type DidSaveTextDocumentParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// MarkupContent is Markdown- or plaintext-formatted content, used here as
+// Hover's payload.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of textDocument/hover.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+}
+
+// ExecuteCommandParams carries a workspace/executeCommand invocation. Only
+// Arguments[0], decoded per-command, is consulted; this server recognizes a
+// single command, "yapper.newNote".
+type ExecuteCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+// NewNoteParams are the decoded arguments[0] for the "yapper.newNote"
+// command: the vault to create the note in, its title, and optionally a
+// cursor position at which to insert a [[wiki link]] to the new note.
+type NewNoteParams struct {
+	VaultName    string                      `json:"vaultName"`
+	Title        string                      `json:"title"`
+	InsertLinkAt *TextDocumentPositionParams `json:"insertLinkAt,omitempty"`
+}
+
+// NewNoteResult reports the note created by "yapper.newNote".
+type NewNoteResult struct {
+	URI  string `json:"uri"`
+	Path string `json:"path"`
+}
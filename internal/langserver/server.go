@@ -0,0 +1,132 @@
+// Package langserver implements a minimal Language Server Protocol front end
+// over an index.Registry, giving editors (Neovim, VS Code) live
+// go-to-definition, find-references, completion (including task IDs), hover,
+// workspace symbols, code lenses, a "yapper.newNote" workspace command, and
+// save/watch-driven reindexing against notes and tasks across every
+// registered vault. See cmd/yapper-lsp for the stdio entry point.
+//
+// Placement note: the request that motivated this package asked for it to
+// live at internal/lsp. That path is already taken in this tree by an
+// unrelated JSON-RPC daemon's LSP front end (a different generation of this
+// codebase, module github.com/jack/yapper/go-note), so this lives at
+// internal/langserver instead.
+package langserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/weakphish/yapper/internal/index"
+)
+
+// Server dispatches LSP requests against registry.
+type Server struct {
+	registry *index.Registry
+}
+
+// NewServer builds a Server backed by registry.
+func NewServer(registry *index.Registry) *Server {
+	return &Server{registry: registry}
+}
+
+// Run processes Content-Length framed LSP messages read from r and writes
+// responses to w until r is exhausted or ctx is canceled.
+func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		req, err := readMessage(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("langserver: read message: %w", err)
+		}
+
+		result, dispatchErr := s.dispatch(ctx, req.Method, req.Params)
+		if req.ID == nil {
+			if dispatchErr != nil {
+				log.Printf("langserver: notification %q failed: %v", req.Method, dispatchErr)
+			}
+			continue
+		}
+
+		resp := response{ID: req.ID}
+		if dispatchErr != nil {
+			resp.Error = &responseError{Code: -32000, Message: dispatchErr.Error()}
+		} else {
+			resp.Result = result
+		}
+		if err := writeMessage(w, resp); err != nil {
+			return fmt.Errorf("langserver: write message: %w", err)
+		}
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "textDocument/definition":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.definition(ctx, p)
+	case "textDocument/references":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.references(ctx, p)
+	case "textDocument/completion":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.completion(ctx, p)
+	case "workspace/symbol":
+		var p WorkspaceSymbolParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.workspaceSymbol(ctx, p)
+	case "textDocument/codeLens":
+		var p CodeLensParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.codeLens(ctx, p)
+	case "workspace/didChangeWatchedFiles":
+		var p DidChangeWatchedFilesParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return nil, s.didChangeWatchedFiles(ctx, p)
+	case "textDocument/didSave":
+		var p DidSaveTextDocumentParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return nil, s.didSave(ctx, p)
+	case "textDocument/hover":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.hover(ctx, p)
+	case "workspace/executeCommand":
+		var p ExecuteCommandParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("decode params: %w", err)
+		}
+		return s.executeCommand(ctx, p)
+	default:
+		return nil, fmt.Errorf("unsupported method %q", method)
+	}
+}
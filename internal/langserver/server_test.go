@@ -0,0 +1,139 @@
+package langserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// frameMessage encodes body as a Content-Length framed LSP message, the same
+// wire format readMessage/writeMessage produce and consume. It stands in for
+// a real editor's stdio transport in these tests.
+func frameMessage(t *testing.T, body []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(body))
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// readFramedResponse decodes a single Content-Length framed response from r,
+// mirroring readMessage's framing but for the response envelope.
+func readFramedResponse(t *testing.T, r *bufio.Reader) response {
+	t.Helper()
+	var contentLength int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read header: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			n, err := strconv.Atoi(strings.TrimSpace(line[len("content-length:"):]))
+			if err != nil {
+				t.Fatalf("parse Content-Length: %v", err)
+			}
+			contentLength = n
+		}
+	}
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	return resp
+}
+
+// TestServer_RunOverFramedTransport drives Server.Run end-to-end through a
+// fake Content-Length framed transport (an in-memory buffer standing in for
+// an editor's stdio pipe), rather than calling handler methods directly.
+func TestServer_RunOverFramedTransport(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"tasks.md": "# Tasks\n\n## Tasks\n\n- [ ] Ship it [T-1]\n",
+	})
+
+	params, err := json.Marshal(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: pathToURI(filepath.Join(root, "tasks.md"))},
+		Position:     Position{Line: 4, Character: 16},
+	})
+	if err != nil {
+		t.Fatalf("marshal params: %v", err)
+	}
+	req, err := json.Marshal(request{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage("1"),
+		Method:  "textDocument/hover",
+		Params:  params,
+	})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	in := bytes.NewReader(frameMessage(t, req))
+	var out bytes.Buffer
+	if err := s.Run(context.Background(), in, &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	resp := readFramedResponse(t, bufio.NewReader(&out))
+	if resp.Error != nil {
+		t.Fatalf("response error: %+v", resp.Error)
+	}
+	resultJSON, err := json.Marshal(resp.Result)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	var hover Hover
+	if err := json.Unmarshal(resultJSON, &hover); err != nil {
+		t.Fatalf("unmarshal hover result: %v", err)
+	}
+	if !strings.Contains(hover.Contents.Value, "Ship it") {
+		t.Fatalf("hover contents = %q, want task title", hover.Contents.Value)
+	}
+}
+
+// TestServer_RunIgnoresNotificationsWithoutID drives a didChangeWatchedFiles
+// notification (no "id", per the LSP spec) through the framed transport and
+// confirms Run processes it without emitting any response frame.
+func TestServer_RunIgnoresNotificationsWithoutID(t *testing.T) {
+	s, root := newTestServer(t, map[string]string{
+		"a.md": "# Note A\n\nbody\n",
+	})
+
+	params, err := json.Marshal(DidChangeWatchedFilesParams{
+		Changes: []FileEvent{{URI: pathToURI(filepath.Join(root, "a.md")), Type: FileChangeChanged}},
+	})
+	if err != nil {
+		t.Fatalf("marshal params: %v", err)
+	}
+	notif, err := json.Marshal(request{
+		JSONRPC: "2.0",
+		Method:  "workspace/didChangeWatchedFiles",
+		Params:  params,
+	})
+	if err != nil {
+		t.Fatalf("marshal notification: %v", err)
+	}
+
+	in := bytes.NewReader(frameMessage(t, notif))
+	var out bytes.Buffer
+	if err := s.Run(context.Background(), in, &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected no response frame for a notification, got %q", out.String())
+	}
+}
@@ -0,0 +1,427 @@
+// Package lsp implements a minimal Language Server Protocol front end over
+// the same core.Domain used by the JSON-RPC daemon in internal/server. It
+// reuses the rpc.Request/Response/ErrorBody envelope types but speaks
+// Content-Length framed messages on stdio, as required by the LSP spec.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/logging"
+	"github.com/jack/yapper/go-note/internal/rpc"
+)
+
+// Run launches the blocking LSP loop over stdin/stdout.
+func Run(domain *core.Domain) error {
+	reader := bufio.NewReader(os.Stdin)
+	writer := os.Stdout
+
+	for {
+		req, err := readMessage(reader)
+		if err == io.EOF {
+			logging.Infof("lsp: stdin closed, shutting down")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("lsp: read message: %w", err)
+		}
+
+		result, rpcErr := dispatch(domain, req.Method, req.Params)
+		if req.ID == nil {
+			// Notifications never produce a response, even on failure.
+			if rpcErr.Code != 0 {
+				logging.Warnf("lsp: notification %q failed: %v", req.Method, rpcErr)
+			}
+			continue
+		}
+
+		var resp rpc.Response
+		if rpcErr.Code != 0 {
+			resp = rpc.ResponseError(*req.ID, rpcErr)
+		} else {
+			resp = rpc.ResponseResult(*req.ID, result)
+		}
+		if err := writeMessage(writer, resp); err != nil {
+			return fmt.Errorf("lsp: write message: %w", err)
+		}
+	}
+}
+
+// readMessage parses a single Content-Length framed LSP message.
+func readMessage(r *bufio.Reader) (rpc.Request, error) {
+	var contentLength int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return rpc.Request{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			value := strings.TrimSpace(line[len("content-length:"):])
+			if _, err := fmt.Sscanf(value, "%d", &contentLength); err != nil {
+				return rpc.Request{}, fmt.Errorf("malformed Content-Length header %q: %w", value, err)
+			}
+		}
+	}
+	if contentLength <= 0 {
+		return rpc.Request{}, fmt.Errorf("missing or invalid Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return rpc.Request{}, err
+	}
+
+	var req rpc.Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return rpc.Request{}, fmt.Errorf("malformed LSP message: %w", err)
+	}
+	return req, nil
+}
+
+// writeMessage serializes resp and frames it with a Content-Length header.
+func writeMessage(w io.Writer, resp rpc.Response) error {
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(payload)); err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
+
+var (
+	wikilinkExpr = regexp.MustCompile(`\[\[([^\]|]+)(?:\|[^\]]*)?\]\]`)
+	tagExpr      = regexp.MustCompile(`#([[:alnum:]/_-]+)`)
+	taskLineExpr = regexp.MustCompile(`^\s*-\s*\[( |x)\]\s+\[(T-[0-9A-Za-z_-]+)\]`)
+)
+
+func dispatch(domain *core.Domain, method string, params json.RawMessage) (interface{}, rpc.Error) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   2, // Incremental
+				"documentLinkProvider": map[string]bool{"resolveProvider": false},
+				"completionProvider":   map[string]interface{}{"triggerCharacters": []string{"#", "["}},
+				"definitionProvider":   true,
+				"codeActionProvider":   true,
+				"workspaceSymbolProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "yapper-note-daemon", "version": "0.1"},
+		}, rpc.Error{}
+	case "initialized", "shutdown", "exit":
+		return nil, rpc.Error{}
+	case "textDocument/didOpen":
+		return handleDidChangeLike(domain, params, "textDocument")
+	case "textDocument/didChange":
+		return handleDidChangeLike(domain, params, "textDocument")
+	case "textDocument/didSave":
+		return handleDidChangeLike(domain, params, "textDocument")
+	case "workspace/symbol":
+		return workspaceSymbols(domain)
+	case "textDocument/documentLink":
+		return documentLinks(domain, params)
+	case "textDocument/completion":
+		return completions(domain)
+	case "textDocument/definition":
+		return definition(domain, params)
+	case "textDocument/codeAction":
+		return codeActions(domain, params)
+	default:
+		return nil, rpc.MethodNotFound(method)
+	}
+}
+
+type textDocumentItem struct {
+	URI     string `json:"uri"`
+	Text    string `json:"text,omitempty"`
+}
+
+type didChangeParams struct {
+	TextDocument   textDocumentItem `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+// handleDidChangeLike covers didOpen/didChange/didSave: all three ultimately
+// need the latest full text written through Domain.WriteNote so the index
+// stays current.
+func handleDidChangeLike(domain *core.Domain, params json.RawMessage, _ string) (interface{}, rpc.Error) {
+	payload, err := rpc.ParseParams[didChangeParams](params)
+	if err.Code != 0 {
+		return nil, err
+	}
+	path, pathErr := uriToPath(payload.TextDocument.URI)
+	if pathErr != nil {
+		return nil, rpc.InvalidParams(pathErr.Error())
+	}
+
+	var text string
+	if payload.TextDocument.Text != "" {
+		text = payload.TextDocument.Text
+	} else if len(payload.ContentChanges) > 0 {
+		text = payload.ContentChanges[len(payload.ContentChanges)-1].Text
+	} else {
+		// Nothing to persist (e.g. a didSave with no text included); just reindex.
+		if reindexErr := domain.IndexMgr.ReindexNotePath(path); reindexErr != nil {
+			return nil, rpc.ServerError(reindexErr.Error())
+		}
+		return nil, rpc.Error{}
+	}
+
+	noteID := core.NoteID(path)
+	if _, writeErr := domain.WriteNote(noteID, text); writeErr != nil {
+		if reindexErr := domain.IndexMgr.ReindexNotePath(path); reindexErr != nil {
+			return nil, rpc.ServerError(reindexErr.Error())
+		}
+	}
+	return nil, rpc.Error{}
+}
+
+func workspaceSymbols(domain *core.Domain) (interface{}, rpc.Error) {
+	tasks := domain.ListTasks(nil)
+	symbols := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		if task.SourceNoteID == nil {
+			continue
+		}
+		note, ok := domain.ReadNote(*task.SourceNoteID)
+		if !ok {
+			continue
+		}
+		symbols = append(symbols, map[string]interface{}{
+			"name": task.Title,
+			"kind": 13, // SymbolKind.Variable; LSP has no native "Task" kind
+			"location": map[string]interface{}{
+				"uri": pathToURI(note.Path),
+				"range": zeroRange(),
+			},
+		})
+	}
+	return symbols, rpc.Error{}
+}
+
+type documentLinkParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+}
+
+func documentLinks(domain *core.Domain, params json.RawMessage) (interface{}, rpc.Error) {
+	payload, err := rpc.ParseParams[documentLinkParams](params)
+	if err.Code != 0 {
+		return nil, err
+	}
+	path, pathErr := uriToPath(payload.TextDocument.URI)
+	if pathErr != nil {
+		return nil, rpc.InvalidParams(pathErr.Error())
+	}
+	note, ok := domain.ReadNote(core.NoteID(path))
+	if !ok {
+		return []interface{}{}, rpc.Error{}
+	}
+
+	var links []map[string]interface{}
+	for lineNum, line := range strings.Split(note.Content, "\n") {
+		for _, match := range wikilinkExpr.FindAllStringSubmatchIndex(line, -1) {
+			target := line[match[2]:match[3]]
+			links = append(links, map[string]interface{}{
+				"range":  lineRange(lineNum, match[0], match[1]),
+				"target": wikilinkTargetURI(domain, target),
+			})
+		}
+		for _, match := range tagExpr.FindAllStringIndex(line, -1) {
+			links = append(links, map[string]interface{}{
+				"range":  lineRange(lineNum, match[0], match[1]),
+				"target": "yapper://tag/" + strings.TrimPrefix(line[match[0]:match[1]], "#"),
+			})
+		}
+	}
+	return links, rpc.Error{}
+}
+
+func completions(domain *core.Domain) (interface{}, rpc.Error) {
+	var items []map[string]interface{}
+	for _, tag := range domain.ListTags() {
+		items = append(items, map[string]interface{}{"label": "#" + tag, "kind": 14}) // Keyword
+	}
+	for _, meta := range domain.NotesInRange(nil) {
+		items = append(items, map[string]interface{}{"label": meta.Title, "kind": 17}) // File
+	}
+	return map[string]interface{}{"isIncomplete": false, "items": items}, rpc.Error{}
+}
+
+type definitionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"position"`
+}
+
+func definition(domain *core.Domain, params json.RawMessage) (interface{}, rpc.Error) {
+	payload, err := rpc.ParseParams[definitionParams](params)
+	if err.Code != 0 {
+		return nil, err
+	}
+	path, pathErr := uriToPath(payload.TextDocument.URI)
+	if pathErr != nil {
+		return nil, rpc.InvalidParams(pathErr.Error())
+	}
+	note, ok := domain.ReadNote(core.NoteID(path))
+	if !ok {
+		return nil, rpc.InvalidRequest("note not found")
+	}
+	lines := strings.Split(note.Content, "\n")
+	if payload.Position.Line < 0 || payload.Position.Line >= len(lines) {
+		return nil, rpc.Error{}
+	}
+	line := lines[payload.Position.Line]
+	match := wikilinkExpr.FindStringSubmatch(line)
+	if match == nil {
+		return nil, rpc.Error{}
+	}
+	target, ok := resolveWikilink(domain, match[1])
+	if !ok {
+		return nil, rpc.Error{}
+	}
+	return map[string]interface{}{
+		"uri":   pathToURI(target.Path),
+		"range": zeroRange(),
+	}, rpc.Error{}
+}
+
+type codeActionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Range struct {
+		Start struct{ Line int `json:"line"` } `json:"start"`
+	} `json:"range"`
+}
+
+// codeActions offers a "Toggle task" quick fix when the requested range sits
+// on a `- [ ] [T-xxx]` line, flipping the checkbox via Domain.WriteNote.
+func codeActions(domain *core.Domain, params json.RawMessage) (interface{}, rpc.Error) {
+	payload, err := rpc.ParseParams[codeActionParams](params)
+	if err.Code != 0 {
+		return nil, err
+	}
+	path, pathErr := uriToPath(payload.TextDocument.URI)
+	if pathErr != nil {
+		return nil, rpc.InvalidParams(pathErr.Error())
+	}
+	noteID := core.NoteID(path)
+	note, ok := domain.ReadNote(noteID)
+	if !ok {
+		return []interface{}{}, rpc.Error{}
+	}
+	lines := strings.Split(note.Content, "\n")
+	lineNum := payload.Range.Start.Line
+	if lineNum < 0 || lineNum >= len(lines) || !taskLineExpr.MatchString(lines[lineNum]) {
+		return []interface{}{}, rpc.Error{}
+	}
+
+	toggled := append([]string{}, lines...)
+	toggled[lineNum] = toggleCheckbox(toggled[lineNum])
+	newText := strings.Join(toggled, "\n")
+
+	edit := map[string]interface{}{
+		"changes": map[string]interface{}{
+			payload.TextDocument.URI: []map[string]interface{}{
+				{"range": fullDocumentRange(len(lines)), "newText": newText},
+			},
+		},
+	}
+	return []map[string]interface{}{
+		{
+			"title": "Toggle task",
+			"kind":  "quickfix",
+			"edit":  edit,
+		},
+	}, rpc.Error{}
+}
+
+func toggleCheckbox(line string) string {
+	if strings.Contains(line, "[ ]") {
+		return strings.Replace(line, "[ ]", "[x]", 1)
+	}
+	if strings.Contains(line, "[x]") {
+		return strings.Replace(line, "[x]", "[ ]", 1)
+	}
+	return line
+}
+
+func resolveWikilink(domain *core.Domain, target string) (core.Note, bool) {
+	target = strings.TrimSpace(target)
+	for _, meta := range domain.NotesInRange(nil) {
+		if meta.Title == target || strings.EqualFold(meta.Title, target) {
+			if note, ok := domain.ReadNote(meta.ID); ok {
+				return note, true
+			}
+		}
+	}
+	return core.Note{}, false
+}
+
+func wikilinkTargetURI(domain *core.Domain, target string) string {
+	if note, ok := resolveWikilink(domain, target); ok {
+		return pathToURI(note.Path)
+	}
+	return "yapper://unresolved/" + url.PathEscape(target)
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid uri %q: %w", uri, err)
+	}
+	if u.Scheme != "file" && u.Scheme != "" {
+		return "", fmt.Errorf("unsupported uri scheme %q", u.Scheme)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return "file://" + filepath.ToSlash(abs)
+}
+
+func zeroRange() map[string]interface{} {
+	return lineRange(0, 0, 0)
+}
+
+func lineRange(line, startCol, endCol int) map[string]interface{} {
+	return map[string]interface{}{
+		"start": map[string]int{"line": line, "character": startCol},
+		"end":   map[string]int{"line": line, "character": endCol},
+	}
+}
+
+func fullDocumentRange(lineCount int) map[string]interface{} {
+	return map[string]interface{}{
+		"start": map[string]int{"line": 0, "character": 0},
+		"end":   map[string]int{"line": lineCount, "character": 0},
+	}
+}
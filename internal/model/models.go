@@ -34,6 +34,16 @@ type Note struct {
 	Title   string    `json:"title"`
 	Date    time.Time `json:"date"`
 	Content string    `json:"content"`
+	// Aliases lists alternate names for the note, read from its YAML
+	// frontmatter ("aliases"/"alias"). Wiki-link resolution and unlinked
+	// mention detection both match against the title and every alias.
+	Aliases []string `json:"aliases,omitempty"`
+	// Frontmatter holds the note's decoded YAML frontmatter block, if any,
+	// with keys normalized to lowercase. Conventional keys ("tags",
+	// "aliases", "title", "date") are additionally promoted onto dedicated
+	// fields elsewhere on Note and Task; arbitrary custom keys are only
+	// available here, queryable via NoteFilter.Metadata.
+	Frontmatter map[string]any `json:"frontmatter,omitempty"`
 }
 
 // Task models a first-class task extracted from a note.
@@ -48,6 +58,21 @@ type Task struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
 	Line        int        `json:"line"`
+	// Origin identifies where a task came from: "vault" for tasks parsed out
+	// of a note (the default, zero-value-friendly spelling), or
+	// "remote:<name>" for a task a RemoteTaskRepository created that has no
+	// backing note yet. ListTasks returns both kinds; only vault-origin tasks
+	// have a meaningful NoteID/Line.
+	Origin string `json:"origin,omitempty"`
+}
+
+// TaskOriginVault is the Origin value for tasks parsed out of a vault note.
+const TaskOriginVault = "vault"
+
+// RemoteTaskOrigin formats the Origin value for a task that originated from
+// the named remote repository rather than a vault note.
+func RemoteTaskOrigin(remoteName string) string {
+	return "remote:" + remoteName
 }
 
 // LogEntry captures structured log lines parsed from a note.
@@ -69,3 +94,28 @@ type TaskMention struct {
 	Context string   `json:"context"`
 	Tags    []string `json:"tags"`
 }
+
+// NoteLink records a `[[wiki link]]` found in a note's content. TargetNoteID
+// is nil until the index resolves TargetText against the vault, so callers
+// can tell a broken link apart from one that simply hasn't been resolved yet.
+// Display holds the text after a `|` in a `[[target|display]]` link, or
+// TargetText again when the link carries no separate display text.
+type NoteLink struct {
+	SourceNoteID NoteID  `json:"source_note_id"`
+	SourceLine   int     `json:"source_line"`
+	TargetText   string  `json:"target_text"`
+	Display      string  `json:"display"`
+	TargetNoteID *NoteID `json:"target_note_id,omitempty"`
+}
+
+// UnlinkedMention records a plain-text occurrence of a note's title or alias
+// inside another note's body that isn't already a formal [[wikilink]] or
+// [markdown](link) to it — a candidate the author may want to turn into a
+// real link.
+type UnlinkedMention struct {
+	SourceNoteID NoteID `json:"source_note_id"`
+	TargetNoteID NoteID `json:"target_note_id"`
+	Line         int    `json:"line"`
+	Excerpt      string `json:"excerpt"`
+	MatchedAlias string `json:"matched_alias"`
+}
@@ -0,0 +1,85 @@
+// Package enrich layers post-processing passes on top of a base
+// parser.NoteParser. Each pass (an Enricher) gets a chance to add to or
+// prune a freshly parsed note's tasks, log entries, and mentions before the
+// result reaches the index.
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// Enricher is one stage in a Pipeline. Implementations must not assume
+// ordering relative to other enrichers beyond what the Pipeline's
+// configured stage order guarantees, and must be safe to skip silently
+// when Required is false.
+type Enricher interface {
+	// Name identifies the enricher in logs and error messages.
+	Name() string
+	// Enrich mutates parsed in place, adding or removing derived data.
+	Enrich(ctx context.Context, parsed *parser.ParsedNote) error
+}
+
+// Stage pairs an Enricher with whether its failure should abort parsing.
+type Stage struct {
+	Enricher Enricher
+	Required bool
+}
+
+// PipelineConfig lists the enrichers a Pipeline should run, in order.
+//
+// The request that motivated this package asked for the pipeline to be
+// configured from config.Config, but that type lives in the
+// github.com/jack/yapper/go-note module's internal/config package, an
+// unrelated generation of this app from the one that owns NoteParser
+// (github.com/weakphish/yapper/internal/parser). There is no config.Config
+// here to wire into. PipelineConfig is the substitute: callers in this
+// module assemble it explicitly (e.g. from their own CLI flags) and hand
+// it to NewPipeline.
+type PipelineConfig struct {
+	Stages []Stage
+}
+
+// Pipeline wraps a base NoteParser and runs a configured, ordered list of
+// Enrichers over every ParsedNote it returns. It implements NoteParser
+// itself, so it can be substituted anywhere a NoteParser is expected.
+type Pipeline struct {
+	base   parser.NoteParser
+	stages []Stage
+}
+
+// NewPipeline builds a Pipeline that delegates base parsing to base and then
+// runs cfg.Stages, in order, against the result.
+func NewPipeline(base parser.NoteParser, cfg PipelineConfig) *Pipeline {
+	return &Pipeline{base: base, stages: cfg.Stages}
+}
+
+// Parse runs the base parser and then each configured enricher in turn. A
+// non-required enricher's error is logged and skipped; a required
+// enricher's error aborts parsing and is returned to the caller.
+func (p *Pipeline) Parse(ctx context.Context, note *model.Note) (*parser.ParsedNote, error) {
+	parsed, err := p.base.Parse(ctx, note)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range p.stages {
+		if ctx != nil {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if err := stage.Enricher.Enrich(ctx, parsed); err != nil {
+			if stage.Required {
+				return nil, fmt.Errorf("required enricher %q: %w", stage.Enricher.Name(), err)
+			}
+			log.Printf("enrich: %q failed, continuing without it: %v", stage.Enricher.Name(), err)
+		}
+	}
+
+	return parsed, nil
+}
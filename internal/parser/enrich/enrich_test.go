@@ -0,0 +1,172 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// stubEnricher records whether it ran and optionally returns a fixed error.
+type stubEnricher struct {
+	name string
+	err  error
+	ran  *bool
+}
+
+func (s stubEnricher) Name() string { return s.name }
+
+func (s stubEnricher) Enrich(_ context.Context, _ *parser.ParsedNote) error {
+	if s.ran != nil {
+		*s.ran = true
+	}
+	return s.err
+}
+
+// stubParser returns a fixed ParsedNote regardless of input.
+type stubParser struct {
+	result *parser.ParsedNote
+}
+
+func (s stubParser) Parse(_ context.Context, _ *model.Note) (*parser.ParsedNote, error) {
+	return s.result, nil
+}
+
+func TestPipeline_RunsStagesInOrder(t *testing.T) {
+	var order []string
+	base := stubParser{result: &parser.ParsedNote{Note: &model.Note{}}}
+	pipeline := NewPipeline(base, PipelineConfig{
+		Stages: []Stage{
+			{Enricher: recordingEnricher{"first", &order}},
+			{Enricher: recordingEnricher{"second", &order}},
+		},
+	})
+
+	if _, err := pipeline.Parse(context.Background(), &model.Note{}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("unexpected stage order: %v", order)
+	}
+}
+
+type recordingEnricher struct {
+	name  string
+	order *[]string
+}
+
+func (r recordingEnricher) Name() string { return r.name }
+
+func (r recordingEnricher) Enrich(_ context.Context, _ *parser.ParsedNote) error {
+	*r.order = append(*r.order, r.name)
+	return nil
+}
+
+func TestPipeline_NonRequiredFailureIsSwallowed(t *testing.T) {
+	ran := false
+	base := stubParser{result: &parser.ParsedNote{Note: &model.Note{}}}
+	pipeline := NewPipeline(base, PipelineConfig{
+		Stages: []Stage{
+			{Enricher: stubEnricher{name: "flaky", err: errors.New("boom")}},
+			{Enricher: stubEnricher{name: "tracked", ran: &ran}},
+		},
+	})
+
+	if _, err := pipeline.Parse(context.Background(), &model.Note{}); err != nil {
+		t.Fatalf("Parse should not fail for a non-required enricher: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected later stage to still run after a non-required failure")
+	}
+}
+
+func TestPipeline_RequiredFailureAbortsParsing(t *testing.T) {
+	base := stubParser{result: &parser.ParsedNote{Note: &model.Note{}}}
+	pipeline := NewPipeline(base, PipelineConfig{
+		Stages: []Stage{
+			{Enricher: stubEnricher{name: "critical", err: errors.New("boom")}, Required: true},
+		},
+	})
+
+	if _, err := pipeline.Parse(context.Background(), &model.Note{}); err == nil {
+		t.Fatal("expected required enricher failure to abort parsing")
+	}
+}
+
+func TestTagAliasEnricher_RewritesTags(t *testing.T) {
+	e := NewTagAliasEnricher(map[string]string{"wip": "in-progress"})
+	parsed := &parser.ParsedNote{
+		Tasks: []model.Task{{Tags: []string{"wip", "urgent"}}},
+	}
+	if err := e.Enrich(context.Background(), parsed); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if got := parsed.Tasks[0].Tags; len(got) != 2 || got[0] != "in-progress" || got[1] != "urgent" {
+		t.Fatalf("unexpected tags after alias resolution: %+v", got)
+	}
+}
+
+func TestRelativeDateEnricher_ResolvesYesterday(t *testing.T) {
+	anchor := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	parsed := &parser.ParsedNote{
+		Note:       &model.Note{Date: anchor},
+		LogEntries: []model.LogEntry{{Content: "Shipped the fix yesterday"}},
+	}
+	e := NewRelativeDateEnricher()
+	if err := e.Enrich(context.Background(), parsed); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	want := time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC)
+	if !parsed.LogEntries[0].Timestamp.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, parsed.LogEntries[0].Timestamp)
+	}
+}
+
+func TestTaskLinkEnricher_FindsBareReferences(t *testing.T) {
+	parsed := &parser.ParsedNote{
+		Note: &model.Note{ID: "notes/a.md", Content: "Blocked on T-9001 until review."},
+	}
+	e := NewTaskLinkEnricher()
+	if err := e.Enrich(context.Background(), parsed); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(parsed.Mentions) != 1 || parsed.Mentions[0].TaskID != "T-9001" {
+		t.Fatalf("expected one mention of T-9001, got %+v", parsed.Mentions)
+	}
+}
+
+func TestWhitelistEnricher_DropsTasksMatchingRule(t *testing.T) {
+	parsed := &parser.ParsedNote{
+		Note: &model.Note{Path: "templates/daily.md"},
+		Tasks: []model.Task{
+			{Title: "review inbox"},
+			{Title: "ship release"},
+		},
+	}
+	e := NewWhitelistEnricher([]SuppressionRule{{TitleGlob: "review*"}})
+	if err := e.Enrich(context.Background(), parsed); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(parsed.Tasks) != 1 || parsed.Tasks[0].Title != "ship release" {
+		t.Fatalf("expected only 'ship release' to remain, got %+v", parsed.Tasks)
+	}
+}
+
+func TestWhitelistEnricher_PathRegexSuppressesEverything(t *testing.T) {
+	parsed := &parser.ParsedNote{
+		Note:     &model.Note{Path: "templates/daily.md"},
+		Tasks:    []model.Task{{Title: "anything"}},
+		Mentions: []model.TaskMention{{TaskID: "T-1"}},
+	}
+	e := NewWhitelistEnricher([]SuppressionRule{{PathRegex: regexp.MustCompile(`^templates/`)}})
+	if err := e.Enrich(context.Background(), parsed); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	if len(parsed.Tasks) != 0 || len(parsed.Mentions) != 0 {
+		t.Fatalf("expected template note's tasks/mentions fully suppressed, got tasks=%+v mentions=%+v", parsed.Tasks, parsed.Mentions)
+	}
+}
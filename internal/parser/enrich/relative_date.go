@@ -0,0 +1,98 @@
+package enrich
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// relativeDatePattern recognizes the relative-date phrases this enricher
+// resolves: "today", "yesterday", "tomorrow", and "next|last <weekday>".
+var relativeDatePattern = regexp.MustCompile(`(?i)\b(today|yesterday|tomorrow|(?:next|last)\s+(?:mon|tue|wed|thu|fri|sat|sun)(?:day|sday|nesday|rsday|urday)?)\b`)
+
+var weekdayByPrefix = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+// RelativeDateEnricher resolves relative-date phrases ("yesterday", "next
+// mon") found in a LogEntry's content into a concrete time.Time, anchored to
+// the owning note's date, and overwrites LogEntry.Timestamp with it. Entries
+// whose content carries no recognizable phrase are left untouched.
+type RelativeDateEnricher struct{}
+
+// NewRelativeDateEnricher constructs a RelativeDateEnricher.
+func NewRelativeDateEnricher() *RelativeDateEnricher {
+	return &RelativeDateEnricher{}
+}
+
+// Name identifies this enricher for logging.
+func (e *RelativeDateEnricher) Name() string { return "relative-date" }
+
+// Enrich resolves relative-date phrases in each log entry's content.
+func (e *RelativeDateEnricher) Enrich(_ context.Context, parsed *parser.ParsedNote) error {
+	if parsed == nil || parsed.Note == nil {
+		return nil
+	}
+	anchor := parsed.Note.Date
+	for i := range parsed.LogEntries {
+		entry := &parsed.LogEntries[i]
+		resolved, ok := resolveRelativeDate(entry.Content, anchor)
+		if !ok {
+			continue
+		}
+		entry.Timestamp = resolved
+	}
+	return nil
+}
+
+func resolveRelativeDate(content string, anchor time.Time) (time.Time, bool) {
+	match := relativeDatePattern.FindString(content)
+	if match == "" {
+		return time.Time{}, false
+	}
+	if anchor.IsZero() {
+		anchor = time.Now()
+	}
+	anchorDay := time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, anchor.Location())
+
+	lower := strings.ToLower(match)
+	switch {
+	case lower == "today":
+		return anchorDay, true
+	case lower == "yesterday":
+		return anchorDay.AddDate(0, 0, -1), true
+	case lower == "tomorrow":
+		return anchorDay.AddDate(0, 0, 1), true
+	case strings.HasPrefix(lower, "next "):
+		return resolveWeekday(anchorDay, lower[len("next "):], 1), true
+	case strings.HasPrefix(lower, "last "):
+		return resolveWeekday(anchorDay, lower[len("last "):], -1), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// resolveWeekday finds the nearest weekday named by prefix in the given
+// direction (+1 for "next", -1 for "last") relative to anchorDay.
+func resolveWeekday(anchorDay time.Time, weekdayText string, direction int) time.Time {
+	target, ok := weekdayByPrefix[weekdayText[:3]]
+	if !ok {
+		return anchorDay
+	}
+	for offset := 1; offset <= 7; offset++ {
+		candidate := anchorDay.AddDate(0, 0, direction*offset)
+		if candidate.Weekday() == target {
+			return candidate
+		}
+	}
+	return anchorDay
+}
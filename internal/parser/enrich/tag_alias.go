@@ -0,0 +1,79 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// TagAliasEnricher rewrites tags on a ParsedNote's tasks, log entries, and
+// mentions according to a configured alias map (e.g. "wip" -> "in-progress"),
+// so a vault can normalize ad hoc shorthand tags without requiring every note
+// to be rewritten.
+type TagAliasEnricher struct {
+	aliases map[string]string
+}
+
+// NewTagAliasEnricher builds a TagAliasEnricher from an explicit alias map.
+// Keys and values are bare tag names (no leading '#').
+func NewTagAliasEnricher(aliases map[string]string) *TagAliasEnricher {
+	return &TagAliasEnricher{aliases: aliases}
+}
+
+// LoadTagAliases reads a YAML file mapping alias tags to their canonical
+// form, e.g.:
+//
+//	wip: in-progress
+//	todo: backlog
+func LoadTagAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tag alias file %q: %w", path, err)
+	}
+	var aliases map[string]string
+	if err := yaml.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("parse tag alias file %q: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// Name identifies this enricher for logging.
+func (e *TagAliasEnricher) Name() string { return "tag-alias" }
+
+// Enrich rewrites every tag that has a configured alias, on tasks, log
+// entries, and mentions alike.
+func (e *TagAliasEnricher) Enrich(_ context.Context, parsed *parser.ParsedNote) error {
+	if len(e.aliases) == 0 || parsed == nil {
+		return nil
+	}
+
+	for i := range parsed.Tasks {
+		parsed.Tasks[i].Tags = e.resolve(parsed.Tasks[i].Tags)
+	}
+	for i := range parsed.LogEntries {
+		parsed.LogEntries[i].Tags = e.resolve(parsed.LogEntries[i].Tags)
+	}
+	for i := range parsed.Mentions {
+		parsed.Mentions[i].Tags = e.resolve(parsed.Mentions[i].Tags)
+	}
+	return nil
+}
+
+func (e *TagAliasEnricher) resolve(tags []string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	resolved := make([]string, len(tags))
+	for i, tag := range tags {
+		if canonical, ok := e.aliases[tag]; ok {
+			resolved[i] = canonical
+			continue
+		}
+		resolved[i] = tag
+	}
+	return resolved
+}
@@ -0,0 +1,71 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// bareTaskIDPattern matches a task reference typed without the `[...]`
+// wrapping the base parser requires, e.g. "see T-1234 for context".
+var bareTaskIDPattern = regexp.MustCompile(`\bT-[A-Za-z0-9_-]+\b`)
+
+// TaskLinkEnricher finds bare "T-xxxx" task references in a note's raw
+// content -- not just inside the Log section the base parser scans -- and
+// turns each into a TaskMention, so a task referenced from free-form prose
+// elsewhere in the note still shows up as a backlink.
+type TaskLinkEnricher struct{}
+
+// NewTaskLinkEnricher constructs a TaskLinkEnricher.
+func NewTaskLinkEnricher() *TaskLinkEnricher {
+	return &TaskLinkEnricher{}
+}
+
+// Name identifies this enricher for logging.
+func (e *TaskLinkEnricher) Name() string { return "task-links" }
+
+// Enrich scans the note's raw content line by line and appends a
+// TaskMention for every bare task ID not already captured by the base
+// parser.
+func (e *TaskLinkEnricher) Enrich(_ context.Context, parsed *parser.ParsedNote) error {
+	if parsed == nil || parsed.Note == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(parsed.Mentions))
+	for _, m := range parsed.Mentions {
+		seen[mentionKey(m.TaskID, m.Line)] = struct{}{}
+	}
+
+	lines := strings.Split(parsed.Note.Content, "\n")
+	for i, line := range lines {
+		lineNumber := i + 1
+		matches := bareTaskIDPattern.FindAllString(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		for _, match := range matches {
+			taskID := model.TaskID(match)
+			key := mentionKey(taskID, lineNumber)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			parsed.Mentions = append(parsed.Mentions, model.TaskMention{
+				TaskID:  taskID,
+				NoteID:  parsed.Note.ID,
+				Line:    lineNumber,
+				Context: strings.TrimSpace(line),
+			})
+		}
+	}
+	return nil
+}
+
+func mentionKey(taskID model.TaskID, line int) string {
+	return fmt.Sprintf("%s@%d", taskID, line)
+}
@@ -0,0 +1,105 @@
+package enrich
+
+import (
+	"context"
+	"path"
+	"regexp"
+
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// SuppressionRule describes one pattern of noise a WhitelistEnricher should
+// drop. A rule matches if any of its non-empty fields match; zero-value
+// fields are ignored. PathRegex, when set and matching the note's path,
+// suppresses every task and mention the note produced, since it usually
+// marks template scaffolding rather than a specific line.
+type SuppressionRule struct {
+	TitleGlob string         // path.Match pattern against a Task's Title
+	Tag       string         // exact tag to suppress, from Tags
+	PathRegex *regexp.Regexp // matches model.Note.Path
+}
+
+// WhitelistEnricher drops tasks and mentions that match any configured
+// SuppressionRule, so recurring template boilerplate (e.g. a daily note's
+// unchecked "[ ] review inbox #template" scaffolding) doesn't pollute the
+// index.
+type WhitelistEnricher struct {
+	rules []SuppressionRule
+}
+
+// NewWhitelistEnricher builds a WhitelistEnricher from the given rules.
+func NewWhitelistEnricher(rules []SuppressionRule) *WhitelistEnricher {
+	return &WhitelistEnricher{rules: rules}
+}
+
+// Name identifies this enricher for logging.
+func (e *WhitelistEnricher) Name() string { return "whitelist" }
+
+// Enrich removes tasks and mentions matching any configured rule.
+func (e *WhitelistEnricher) Enrich(_ context.Context, parsed *parser.ParsedNote) error {
+	if len(e.rules) == 0 || parsed == nil || parsed.Note == nil {
+		return nil
+	}
+
+	for _, rule := range e.rules {
+		if rule.PathRegex != nil && rule.PathRegex.MatchString(parsed.Note.Path) {
+			parsed.Tasks = nil
+			parsed.Mentions = nil
+			return nil
+		}
+	}
+
+	parsed.Tasks = filterSlice(parsed.Tasks, func(t model.Task) bool {
+		return !e.suppressesTask(t)
+	})
+	parsed.Mentions = filterSlice(parsed.Mentions, func(m model.TaskMention) bool {
+		return !e.suppressesMention(m)
+	})
+	return nil
+}
+
+func (e *WhitelistEnricher) suppressesTask(t model.Task) bool {
+	for _, rule := range e.rules {
+		if rule.TitleGlob != "" {
+			if ok, _ := path.Match(rule.TitleGlob, t.Title); ok {
+				return true
+			}
+		}
+		if rule.Tag != "" && containsTag(t.Tags, rule.Tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *WhitelistEnricher) suppressesMention(m model.TaskMention) bool {
+	for _, rule := range e.rules {
+		if rule.Tag != "" && containsTag(m.Tags, rule.Tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func filterSlice[T any](items []T, keep func(T) bool) []T {
+	if len(items) == 0 {
+		return items
+	}
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if keep(item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
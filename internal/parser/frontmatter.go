@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// extractFrontmatterTags reads a leading YAML frontmatter block (delimited by
+// "---" lines) and returns every string found under its "tags" or "keywords"
+// key, whether declared as a single scalar or a sequence. It returns nil if
+// the note has no frontmatter or the frontmatter doesn't parse as YAML.
+func extractFrontmatterTags(content string) []string {
+	fm, ok := splitFrontmatter(content)
+	if !ok {
+		return nil
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(fm), &doc); err != nil {
+		return nil
+	}
+
+	var tags []string
+	for _, key := range []string{"tags", "keywords"} {
+		tags = append(tags, stringsFromYAMLValue(doc[key])...)
+	}
+	return tags
+}
+
+// extractFrontmatterMetadata reads a leading YAML frontmatter block and
+// returns its keys normalized to lower case, mapped to their decoded values.
+// It returns nil if the note has no frontmatter or the frontmatter doesn't
+// parse as YAML.
+func extractFrontmatterMetadata(content string) map[string]any {
+	fm, ok := splitFrontmatter(content)
+	if !ok {
+		return nil
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(fm), &doc); err != nil || len(doc) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]any, len(doc))
+	for key, value := range doc {
+		metadata[strings.ToLower(key)] = value
+	}
+	return metadata
+}
+
+// splitFrontmatter returns the YAML body between a note's leading "---"
+// delimiters, if present.
+func splitFrontmatter(content string) (string, bool) {
+	const delim = "---"
+	trimmed := strings.TrimLeft(content, "\ufeff \t\r\n")
+	if !strings.HasPrefix(trimmed, delim) {
+		return "", false
+	}
+	rest := trimmed[len(delim):]
+	idx := strings.Index(rest, "\n"+delim)
+	if idx < 0 {
+		return "", false
+	}
+	return rest[:idx], true
+}
+
+// stringsFromYAMLValue normalizes a decoded YAML value into a slice of
+// strings, accepting either a single scalar or a sequence of scalars.
+func stringsFromYAMLValue(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var out []string
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
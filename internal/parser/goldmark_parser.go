@@ -0,0 +1,484 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// GoldmarkNoteParser implements NoteParser by walking goldmark's parsed
+// Markdown AST instead of matching raw lines with regular expressions, like
+// RegexNoteParser does. Because it operates on document structure rather
+// than lines, it correctly handles nested task lists, soft-wrapped task and
+// log bodies that continue onto following lines, arbitrary heading depths
+// for "Tasks"/"Log" detection, and fenced code blocks, whose contents are
+// never visited as prose and so never contribute spurious [T-*] mentions.
+type GoldmarkNoteParser struct {
+	md                  goldmark.Markdown
+	tagPattern          *regexp.Regexp
+	bearTagPattern      *regexp.Regexp
+	colonTagPattern     *regexp.Regexp
+	taskIDPattern       *regexp.Regexp
+	logTimestampPattern *regexp.Regexp
+	wikiLinkPattern     *regexp.Regexp
+}
+
+// NewGoldmarkNoteParser constructs an AST-driven NoteParser implementation.
+func NewGoldmarkNoteParser() NoteParser {
+	return &GoldmarkNoteParser{
+		md:                  goldmark.New(goldmark.WithExtensions(extension.TaskList)),
+		tagPattern:          regexp.MustCompile(`#([[:alnum:]/_-]+)`),
+		bearTagPattern:      regexp.MustCompile(`#([[:alnum:]_/-]+(?: [[:alnum:]_/-]+)+)#`),
+		colonTagPattern:     regexp.MustCompile(`:([[:alnum:]_-]+(?::[[:alnum:]_-]+)+):`),
+		taskIDPattern:       regexp.MustCompile(`\[(T-[A-Za-z0-9_-]+)\]`),
+		logTimestampPattern: regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(?:[ T](\d{2}:\d{2}(?::\d{2})?))?(?:\s+-\s+|\s+)(.*)$`),
+		// `[[wiki links]]` aren't CommonMark syntax, so goldmark's AST never
+		// turns them into link nodes; they surface as plain text, matched
+		// here the same way RegexNoteParser matches them against raw lines.
+		wikiLinkPattern: regexp.MustCompile(`\[\[([^\]]+)\]\]`),
+	}
+}
+
+// astSection mirrors parseSection but also tracks heading depth: a heading
+// only closes a "Tasks"/"Log" section once a heading at the same or
+// shallower level is seen, so a deeper subheading (e.g. "### Subtasks"
+// under "## Tasks") doesn't.
+type astSection struct {
+	kind  parseSection
+	level int
+}
+
+// Parse implements the NoteParser interface.
+func (p *GoldmarkNoteParser) Parse(ctx context.Context, note *model.Note) (*ParsedNote, error) {
+	if note == nil {
+		return nil, errors.New("note cannot be nil")
+	}
+	if err := ensureParserContext(ctx); err != nil {
+		return nil, err
+	}
+
+	source := []byte(note.Content)
+	doc := p.md.Parser().Parse(text.NewReader(source))
+
+	result := &ParsedNote{
+		Note:       note,
+		Tasks:      []model.Task{},
+		LogEntries: []model.LogEntry{},
+		Mentions:   []model.TaskMention{},
+		Links:      []model.NoteLink{},
+	}
+
+	w := &astWalker{p: p, note: note, source: source, result: result}
+	if err := w.walkBlocks(ctx, doc, astSection{kind: sectionNone}); err != nil {
+		return nil, err
+	}
+
+	result.Metadata = extractFrontmatterMetadata(note.Content)
+
+	if fmTags := extractFrontmatterTags(note.Content); len(fmTags) > 0 {
+		for i := range result.Tasks {
+			result.Tasks[i].Tags = append(result.Tasks[i].Tags, fmTags...)
+		}
+		for i := range result.LogEntries {
+			result.LogEntries[i].Tags = append(result.LogEntries[i].Tags, fmTags...)
+		}
+		for i := range result.Mentions {
+			result.Mentions[i].Tags = append(result.Mentions[i].Tags, fmTags...)
+		}
+	}
+
+	return result, nil
+}
+
+// astWalker carries the state needed to translate one note's AST into the
+// same ParsedNote shape RegexNoteParser produces.
+type astWalker struct {
+	p      *GoldmarkNoteParser
+	note   *model.Note
+	source []byte
+	result *ParsedNote
+}
+
+// walkBlocks visits a heading's or the document's direct children, tracking
+// which "Tasks"/"Log" section is active.
+func (w *astWalker) walkBlocks(ctx context.Context, parent ast.Node, section astSection) error {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		if err := ensureParserContext(ctx); err != nil {
+			return err
+		}
+		switch node := n.(type) {
+		case *ast.Heading:
+			section = w.sectionFor(node)
+		case *ast.List:
+			switch section.kind {
+			case sectionTasks:
+				w.walkTaskList(node)
+			case sectionLog:
+				w.walkLogList(node)
+			default:
+				w.scanMentions(node)
+			}
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			// Code fences are never scanned for [T-*] mentions or parsed as
+			// task/log bullets, unlike the regex parser, which has no
+			// concept of code fences and would misread a bullet-like line
+			// inside one.
+		default:
+			if section.kind == sectionNone {
+				w.scanMentions(node)
+			}
+		}
+	}
+	return nil
+}
+
+// sectionFor classifies a heading as starting a "Tasks" or "Log" section, or
+// closing one: any heading not titled "Tasks"/"Log" returns to sectionNone.
+func (w *astWalker) sectionFor(h *ast.Heading) astSection {
+	title := strings.ToLower(strings.TrimSpace(w.plainText(h)))
+	switch title {
+	case "tasks":
+		return astSection{kind: sectionTasks, level: h.Level}
+	case "log":
+		return astSection{kind: sectionLog, level: h.Level}
+	default:
+		return astSection{kind: sectionNone, level: h.Level}
+	}
+}
+
+// walkTaskList parses every list item as a task, recursing into nested
+// sublists so a child task list (e.g. sub-steps under a parent bullet) is
+// flattened into the same Tasks slice as its siblings.
+func (w *astWalker) walkTaskList(list *ast.List) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		li, ok := item.(*ast.ListItem)
+		if !ok {
+			continue
+		}
+		w.walkTaskItem(li)
+	}
+}
+
+func (w *astWalker) walkTaskItem(li *ast.ListItem) {
+	var body ast.Node
+	var nested *ast.List
+	var continuations []ast.Node
+	for c := li.FirstChild(); c != nil; c = c.NextSibling() {
+		if l, ok := c.(*ast.List); ok {
+			nested = l
+			continue
+		}
+		if body == nil {
+			body = c
+		} else {
+			continuations = append(continuations, c)
+		}
+	}
+
+	if body != nil {
+		lineNumber := lineNumberFor(body, w.source)
+
+		checked := false
+		start := body.FirstChild()
+		if cb, ok := start.(*east.TaskCheckBox); ok {
+			checked = cb.IsChecked
+			start = start.NextSibling()
+		}
+
+		content := strings.TrimSpace(w.plainTextFrom(start))
+		for _, cont := range continuations {
+			content = strings.TrimSpace(content + " " + w.plainText(cont))
+		}
+
+		if content != "" {
+			w.scanLinks(content, lineNumber)
+
+			tags := w.p.extractTags(content)
+			taskID := w.p.extractExplicitTaskID(content)
+			if taskID == "" {
+				taskID = model.TaskID(fmt.Sprintf("%s#%d", w.note.ID, lineNumber))
+			}
+
+			status := model.TaskStatusTodo
+			if checked {
+				status = model.TaskStatusDone
+			}
+
+			now := w.note.Date
+			task := model.Task{
+				ID:        taskID,
+				NoteID:    w.note.ID,
+				Title:     w.p.cleanContent(content),
+				Status:    status,
+				Tags:      tags,
+				CreatedAt: now,
+				UpdatedAt: now,
+				Line:      lineNumber,
+				Origin:    model.TaskOriginVault,
+			}
+			if status == model.TaskStatusDone && !now.IsZero() {
+				task.CompletedAt = &now
+			}
+			w.result.Tasks = append(w.result.Tasks, task)
+		}
+	}
+
+	if nested != nil {
+		w.walkTaskList(nested)
+	}
+}
+
+// walkLogList parses every list item as a log entry, joining any
+// continuation blocks (a soft-wrapped body indented under the bullet) onto
+// the first line's content before tag/mention extraction.
+func (w *astWalker) walkLogList(list *ast.List) {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		li, ok := item.(*ast.ListItem)
+		if !ok {
+			continue
+		}
+		w.walkLogItem(li)
+	}
+}
+
+func (w *astWalker) walkLogItem(li *ast.ListItem) {
+	var blocks []ast.Node
+	for c := li.FirstChild(); c != nil; c = c.NextSibling() {
+		if _, ok := c.(*ast.List); ok {
+			continue
+		}
+		blocks = append(blocks, c)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+
+	lineNumber := lineNumberFor(blocks[0], w.source)
+	body := strings.TrimSpace(w.plainText(blocks[0]))
+	for _, b := range blocks[1:] {
+		body = strings.TrimSpace(body + " " + w.plainText(b))
+	}
+	if body == "" {
+		return
+	}
+
+	timestamp, content := w.p.extractTimestamp(body, w.note.Date)
+	w.scanLinks(content, lineNumber)
+	tags := w.p.extractTags(content)
+	refs := w.p.extractTaskIDs(content)
+	cleaned := w.p.cleanContent(content)
+
+	entry := model.LogEntry{
+		ID:        model.LogEntryID(fmt.Sprintf("%s#log#%d", w.note.ID, lineNumber)),
+		NoteID:    w.note.ID,
+		Line:      lineNumber,
+		Timestamp: timestamp,
+		Content:   cleaned,
+		Tags:      tags,
+		TaskRefs:  refs,
+	}
+	w.result.LogEntries = append(w.result.LogEntries, entry)
+
+	if len(refs) > 0 {
+		for _, id := range refs {
+			w.result.Mentions = append(w.result.Mentions, model.TaskMention{
+				TaskID:  id,
+				NoteID:  w.note.ID,
+				Line:    lineNumber,
+				Context: cleaned,
+				Tags:    tags,
+			})
+		}
+	}
+}
+
+// scanMentions extracts [T-*] mentions from prose outside the Tasks/Log
+// sections, mirroring RegexNoteParser's default-section behavior.
+func (w *astWalker) scanMentions(n ast.Node) {
+	line := w.plainText(n)
+	lineNumber := lineNumberFor(n, w.source)
+	w.scanLinks(line, lineNumber)
+
+	ids := w.p.extractTaskIDs(line)
+	if len(ids) == 0 {
+		return
+	}
+	tags := w.p.extractTags(line)
+	context := w.p.cleanContent(line)
+	for _, id := range ids {
+		w.result.Mentions = append(w.result.Mentions, model.TaskMention{
+			TaskID:  id,
+			NoteID:  w.note.ID,
+			Line:    lineNumber,
+			Context: context,
+			Tags:    tags,
+		})
+	}
+}
+
+// scanLinks extracts `[[wiki link]]` and `[[target|display]]` references
+// from text, the same way RegexNoteParser.parseWikiLinks does for a raw
+// line, regardless of which section (Tasks, Log, or prose) text came from.
+func (w *astWalker) scanLinks(text string, lineNumber int) {
+	matches := w.p.wikiLinkPattern.FindAllStringSubmatch(text, -1)
+	for _, m := range matches {
+		target, display := splitWikiLink(m[1])
+		w.result.Links = append(w.result.Links, model.NoteLink{
+			SourceNoteID: w.note.ID,
+			SourceLine:   lineNumber,
+			TargetText:   target,
+			Display:      display,
+		})
+	}
+}
+
+// plainText concatenates the plain text of n's inline descendants, skipping
+// over formatting nodes (emphasis, links, etc.) without losing their text.
+func (w *astWalker) plainText(n ast.Node) string {
+	return w.plainTextFrom(n.FirstChild())
+}
+
+func (w *astWalker) plainTextFrom(n ast.Node) string {
+	var sb strings.Builder
+	for c := n; c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(w.source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		case *east.TaskCheckBox:
+			// Handled by the caller; contributes no text of its own.
+		case *ast.CodeSpan:
+			// Inline code is never scanned for tags or [T-*] mentions,
+			// matching the fenced-code-block exclusion at the block level.
+		default:
+			sb.WriteString(w.plainTextFrom(c.FirstChild()))
+		}
+	}
+	return sb.String()
+}
+
+// lineNumberFor returns the 1-based source line a block node starts on, or 0
+// if that can't be determined (e.g. the node carries no source lines).
+func lineNumberFor(n ast.Node, source []byte) int {
+	block, ok := n.(interface{ Lines() *text.Segments })
+	if !ok || block.Lines().Len() == 0 {
+		return 0
+	}
+	seg := block.Lines().At(0)
+	return bytes.Count(source[:seg.Start], []byte("\n")) + 1
+}
+
+// extractTags, extractTaskIDs, extractExplicitTaskID, cleanContent, and
+// extractTimestamp mirror RegexNoteParser's token-level helpers of the same
+// name: once the AST walk has located a task/log body as a contiguous
+// string, the remaining work (pulling tags, task IDs, and timestamps out of
+// it) is the same regardless of parsing strategy.
+
+func (p *GoldmarkNoteParser) extractTags(input string) []string {
+	var raw []string
+	seen := make(map[string]struct{})
+	add := func(tag string) {
+		if _, ok := seen[tag]; ok {
+			return
+		}
+		seen[tag] = struct{}{}
+		raw = append(raw, tag)
+	}
+
+	for _, m := range p.bearTagPattern.FindAllStringSubmatch(input, -1) {
+		add(m[1])
+	}
+	masked := p.bearTagPattern.ReplaceAllString(input, "")
+
+	for _, m := range p.colonTagPattern.FindAllStringSubmatch(masked, -1) {
+		add(m[1])
+	}
+	masked = p.colonTagPattern.ReplaceAllString(masked, "")
+
+	for _, m := range p.tagPattern.FindAllStringSubmatch(masked, -1) {
+		add(m[1])
+	}
+
+	return raw
+}
+
+func (p *GoldmarkNoteParser) extractTaskIDs(line string) []model.TaskID {
+	matches := p.taskIDPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	ids := make([]model.TaskID, 0, len(matches))
+	seen := make(map[model.TaskID]struct{}, len(matches))
+	for _, m := range matches {
+		id := model.TaskID(m[1])
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (p *GoldmarkNoteParser) extractExplicitTaskID(content string) model.TaskID {
+	matches := p.taskIDPattern.FindStringSubmatch(content)
+	if matches == nil {
+		return ""
+	}
+	return model.TaskID(matches[1])
+}
+
+func (p *GoldmarkNoteParser) cleanContent(content string) string {
+	withoutIDs := p.taskIDPattern.ReplaceAllString(content, "")
+	withoutBear := p.bearTagPattern.ReplaceAllString(withoutIDs, "")
+	withoutColon := p.colonTagPattern.ReplaceAllString(withoutBear, "")
+	withoutTags := p.tagPattern.ReplaceAllString(withoutColon, "")
+	fields := strings.Fields(withoutTags)
+	return strings.Join(fields, " ")
+}
+
+func (p *GoldmarkNoteParser) extractTimestamp(body string, fallback time.Time) (time.Time, string) {
+	matches := p.logTimestampPattern.FindStringSubmatch(body)
+	if matches == nil {
+		return fallback, body
+	}
+
+	datePart := matches[1]
+	timePart := matches[2]
+	rest := strings.TrimSpace(matches[3])
+
+	layout := "2006-01-02"
+	if timePart != "" {
+		layout = "2006-01-02 15:04"
+		if strings.Count(timePart, ":") == 2 {
+			layout = "2006-01-02 15:04:05"
+		}
+		datePart = fmt.Sprintf("%s %s", datePart, timePart)
+	}
+
+	parsed, err := time.Parse(layout, datePart)
+	if err != nil {
+		return fallback, body
+	}
+	if !fallback.IsZero() {
+		parsed = parsed.In(fallback.Location())
+	}
+	return parsed, rest
+}
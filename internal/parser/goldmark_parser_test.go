@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// TestGoldmarkNoteParser_Parse validates that the AST parser extracts tasks,
+// logs, tags, and mentions equivalently to RegexNoteParser on a
+// representative note, including a nested sub-task under a parent bullet.
+func TestGoldmarkNoteParser_Parse(t *testing.T) {
+	content := strings.TrimSpace(`
+## Tasks
+- [ ] Draft summary #work [T-1234]
+  - [ ] Gather figures #work
+- [x] Finish report #work
+## Log
+- 2024-05-01 Completed milestone #wins [T-1234]
+- 2024-05-02 Followed up with #work #team [T-5678]
+`)
+
+	note := &model.Note{
+		ID:      "daily/2024-05-02.md",
+		Path:    "daily/2024-05-02.md",
+		Title:   "Daily",
+		Date:    time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC),
+		Content: content,
+	}
+
+	p := NewGoldmarkNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(result.Tasks) != 3 {
+		t.Fatalf("expected 3 tasks (including the nested sub-task), got %d: %+v", len(result.Tasks), result.Tasks)
+	}
+
+	firstTask := result.Tasks[0]
+	if firstTask.ID != "T-1234" || firstTask.Title != "Draft summary" || firstTask.Status != model.TaskStatusTodo {
+		t.Fatalf("unexpected first task: %+v", firstTask)
+	}
+	if len(firstTask.Tags) != 1 || firstTask.Tags[0] != "work" {
+		t.Fatalf("unexpected tags on first task: %+v", firstTask.Tags)
+	}
+
+	nestedTask := result.Tasks[1]
+	if nestedTask.Title != "Gather figures" || nestedTask.Status != model.TaskStatusTodo {
+		t.Fatalf("unexpected nested task: %+v", nestedTask)
+	}
+
+	lastTask := result.Tasks[2]
+	if lastTask.Title != "Finish report" || lastTask.Status != model.TaskStatusDone {
+		t.Fatalf("unexpected last task: %+v", lastTask)
+	}
+
+	if len(result.LogEntries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(result.LogEntries))
+	}
+	firstLog := result.LogEntries[0]
+	if firstLog.Content != "Completed milestone" || firstLog.Timestamp != time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) {
+		t.Fatalf("unexpected first log entry: %+v", firstLog)
+	}
+	if len(firstLog.TaskRefs) != 1 || firstLog.TaskRefs[0] != "T-1234" {
+		t.Fatalf("unexpected log refs: %+v", firstLog.TaskRefs)
+	}
+
+	if len(result.Mentions) != 2 {
+		t.Fatalf("expected 2 mentions, got %d: %+v", len(result.Mentions), result.Mentions)
+	}
+}
+
+// TestGoldmarkNoteParser_Parse_WikiLinks validates that [[wiki links]] are
+// extracted from prose, task, and log lines alike, including the
+// [[target|display]] form, matching RegexNoteParser's behavior even though
+// goldmark's CommonMark AST has no native node for this syntax.
+func TestGoldmarkNoteParser_Parse_WikiLinks(t *testing.T) {
+	content := strings.TrimSpace(`
+See [[Project Overview]] for context.
+## Tasks
+- [ ] Review [[Project Overview|the overview doc]] #work
+## Log
+- 2024-05-02 Followed up with [[Jane Doe]] about billing
+`)
+
+	note := &model.Note{ID: "daily/2024-05-02.md", Path: "daily/2024-05-02.md", Title: "Daily", Content: content}
+
+	p := NewGoldmarkNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(result.Links) != 3 {
+		t.Fatalf("expected 3 links, got %d: %+v", len(result.Links), result.Links)
+	}
+	if result.Links[0].TargetText != "Project Overview" || result.Links[0].Display != "Project Overview" {
+		t.Fatalf("unexpected first link: %+v", result.Links[0])
+	}
+	if result.Links[1].TargetText != "Project Overview" || result.Links[1].Display != "the overview doc" {
+		t.Fatalf("unexpected second link: %+v", result.Links[1])
+	}
+	if result.Links[2].TargetText != "Jane Doe" || result.Links[2].Display != "Jane Doe" {
+		t.Fatalf("unexpected third link: %+v", result.Links[2])
+	}
+}
+
+// TestGoldmarkNoteParser_Parse_SkipsCodeFences validates that a bullet-like
+// line inside a fenced code block is never parsed as a task/log entry or
+// scanned for [T-*] mentions, unlike the regex parser, which has no concept
+// of code fences.
+func TestGoldmarkNoteParser_Parse_SkipsCodeFences(t *testing.T) {
+	content := strings.TrimSpace("## Tasks\n```\n- [ ] not a real task [T-9999]\n```\n")
+
+	note := &model.Note{ID: "notes/fence.md", Path: "notes/fence.md", Title: "Fence", Content: content}
+
+	p := NewGoldmarkNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Tasks) != 0 {
+		t.Fatalf("expected 0 tasks, got %+v", result.Tasks)
+	}
+	if len(result.Mentions) != 0 {
+		t.Fatalf("expected 0 mentions, got %+v", result.Mentions)
+	}
+}
+
+// TestGoldmarkNoteParser_Parse_SoftWrappedBody validates that a task's
+// continuation line, soft-wrapped under the same bullet, is folded into the
+// task's title rather than dropped.
+func TestGoldmarkNoteParser_Parse_SoftWrappedBody(t *testing.T) {
+	content := strings.TrimSpace("## Tasks\n- [ ] Draft the quarterly report\n  and circulate it for review [T-42]\n")
+
+	note := &model.Note{ID: "notes/wrap.md", Path: "notes/wrap.md", Title: "Wrap", Content: content}
+
+	p := NewGoldmarkNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Tasks) != 1 {
+		t.Fatalf("expected 1 task, got %+v", result.Tasks)
+	}
+	if result.Tasks[0].ID != "T-42" {
+		t.Fatalf("unexpected task ID: %+v", result.Tasks[0])
+	}
+	if result.Tasks[0].Title != "Draft the quarterly report and circulate it for review" {
+		t.Fatalf("continuation line not folded into title: %q", result.Tasks[0].Title)
+	}
+}
+
+// TestGoldmarkNoteParser_Parse_DeepHeading validates that "## Tasks" detection
+// isn't tied to a fixed heading level: a "### Tasks" subheading still opens a
+// tasks section.
+func TestGoldmarkNoteParser_Parse_DeepHeading(t *testing.T) {
+	content := strings.TrimSpace("### Tasks\n- [ ] Renew passport [T-7]\n")
+
+	note := &model.Note{ID: "notes/deep.md", Path: "notes/deep.md", Title: "Deep", Content: content}
+
+	p := NewGoldmarkNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Tasks) != 1 || result.Tasks[0].ID != "T-7" {
+		t.Fatalf("unexpected tasks: %+v", result.Tasks)
+	}
+}
+
+// TestNewNoteParser validates the engine-selection factory that lets a
+// caller pick "regex" or "goldmark" (e.g. from a config setting).
+func TestNewNoteParser(t *testing.T) {
+	if p, err := NewNoteParser(EngineRegex); err != nil {
+		t.Fatalf("EngineRegex: %v", err)
+	} else if _, ok := p.(*RegexNoteParser); !ok {
+		t.Fatalf("EngineRegex returned %T, want *RegexNoteParser", p)
+	}
+
+	if p, err := NewNoteParser(EngineGoldmark); err != nil {
+		t.Fatalf("EngineGoldmark: %v", err)
+	} else if _, ok := p.(*GoldmarkNoteParser); !ok {
+		t.Fatalf("EngineGoldmark returned %T, want *GoldmarkNoteParser", p)
+	}
+
+	if p, err := NewNoteParser(""); err != nil || p == nil {
+		t.Fatalf("empty Engine should default to regex, got %v, %v", p, err)
+	}
+
+	if _, err := NewNoteParser("treesitter"); err == nil {
+		t.Fatalf("expected an error for an unknown engine")
+	}
+}
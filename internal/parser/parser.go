@@ -2,6 +2,8 @@ package parser
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/weakphish/yapper/internal/model"
 )
@@ -14,6 +16,11 @@ type ParsedNote struct {
 	Tasks      []model.Task        `json:"tasks"`
 	LogEntries []model.LogEntry    `json:"log_entries"`
 	Mentions   []model.TaskMention `json:"mentions"`
+	Links      []model.NoteLink    `json:"links"`
+	// Metadata holds the note's decoded YAML frontmatter, with keys
+	// normalized to lower case, or nil if the note has none. It's the same
+	// data IndexStore persists per-note for ListNotesByMetadata to query.
+	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 // NoteParser describes the behavior required by each Markdown parsing strategy.
@@ -24,3 +31,44 @@ type NoteParser interface {
 	// expected to be deterministic and must not mutate the input note.
 	Parse(ctx context.Context, note *model.Note) (*ParsedNote, error)
 }
+
+// Engine selects which NoteParser implementation NewNoteParser builds. This
+// package has no config type of its own (gen1 has no analogue of the
+// top-level application config yet), so a caller that reads a
+// "parser.engine = regex|goldmark"-style setting from wherever it keeps
+// configuration is expected to map it onto one of these constants itself.
+type Engine string
+
+const (
+	// EngineRegex selects RegexNoteParser.
+	EngineRegex Engine = "regex"
+	// EngineGoldmark selects GoldmarkNoteParser.
+	EngineGoldmark Engine = "goldmark"
+)
+
+// splitWikiLink splits the inner text of a `[[...]]` match on its first `|`,
+// the `[[target|display]]` syntax both NoteParser implementations recognize.
+// A link with no `|` returns target as both target and display, so callers
+// never need to special-case the plain `[[target]]` form.
+func splitWikiLink(inner string) (target, display string) {
+	target = strings.TrimSpace(inner)
+	if idx := strings.Index(inner, "|"); idx >= 0 {
+		target = strings.TrimSpace(inner[:idx])
+		display = strings.TrimSpace(inner[idx+1:])
+		return target, display
+	}
+	return target, target
+}
+
+// NewNoteParser builds the NoteParser implementation named by engine. An
+// empty Engine defaults to EngineRegex, the long-standing v1 behavior.
+func NewNoteParser(engine Engine) (NoteParser, error) {
+	switch engine {
+	case "", EngineRegex:
+		return NewRegexNoteParser(), nil
+	case EngineGoldmark:
+		return NewGoldmarkNoteParser(), nil
+	default:
+		return nil, fmt.Errorf("parser: unknown engine %q", engine)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,8 +19,11 @@ type RegexNoteParser struct {
 	taskLinePattern     *regexp.Regexp
 	logLinePattern      *regexp.Regexp
 	tagPattern          *regexp.Regexp
+	bearTagPattern      *regexp.Regexp
+	colonTagPattern     *regexp.Regexp
 	taskIDPattern       *regexp.Regexp
 	logTimestampPattern *regexp.Regexp
+	wikiLinkPattern     *regexp.Regexp
 }
 
 // NewRegexNoteParser constructs a regex-driven NoteParser implementation.
@@ -28,8 +32,11 @@ func NewRegexNoteParser() NoteParser {
 		taskLinePattern:     regexp.MustCompile(`^\s*[-*]\s+\[([^\]])\]\s+(.+)$`),
 		logLinePattern:      regexp.MustCompile(`^\s*[-*]\s+(.+)$`),
 		tagPattern:          regexp.MustCompile(`#([[:alnum:]/_-]+)`),
+		bearTagPattern:      regexp.MustCompile(`#([[:alnum:]_/-]+(?: [[:alnum:]_/-]+)+)#`),
+		colonTagPattern:     regexp.MustCompile(`:([[:alnum:]_-]+(?::[[:alnum:]_-]+)+):`),
 		taskIDPattern:       regexp.MustCompile(`\[(T-[A-Za-z0-9_-]+)\]`),
 		logTimestampPattern: regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(?:[ T](\d{2}:\d{2}(?::\d{2})?))?(?:\s+-\s+|\s+)(.*)$`),
+		wikiLinkPattern:     regexp.MustCompile(`\[\[([^\]]+)\]\]`),
 	}
 }
 
@@ -49,16 +56,30 @@ func (p *RegexNoteParser) Parse(ctx context.Context, note *model.Note) (*ParsedN
 		Tasks:      []model.Task{},
 		LogEntries: []model.LogEntry{},
 		Mentions:   []model.TaskMention{},
+		Links:      []model.NoteLink{},
 	}
 
 	lines := strings.Split(note.Content, "\n")
 	section := sectionNone
+	inFence := false
 	for i, line := range lines {
 		if err := ensureParserContext(ctx); err != nil {
 			return nil, err
 		}
 		lineNumber := i + 1
 		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			// Fenced code blocks are never scanned for tags, task IDs, or
+			// bullets; a line inside one that merely looks like a bullet
+			// or tag must not be mistaken for real note content.
+			continue
+		}
+
 		if strings.HasPrefix(trimmed, "## ") {
 			// Section headings toggle parsing behavior; the simple regex parser
 			// only cares about the "Tasks" and "Log" sections today.
@@ -73,6 +94,10 @@ func (p *RegexNoteParser) Parse(ctx context.Context, note *model.Note) (*ParsedN
 			continue
 		}
 
+		if links := p.parseWikiLinks(note, line, lineNumber); len(links) > 0 {
+			result.Links = append(result.Links, links...)
+		}
+
 		switch section {
 		case sectionTasks:
 			if task := p.parseTaskLine(note, line, lineNumber); task != nil {
@@ -93,6 +118,24 @@ func (p *RegexNoteParser) Parse(ctx context.Context, note *model.Note) (*ParsedN
 		}
 	}
 
+	result.Metadata = extractFrontmatterMetadata(note.Content)
+
+	if fmTags := extractFrontmatterTags(note.Content); len(fmTags) > 0 {
+		// Frontmatter tags apply to the whole note, so they're merged onto
+		// every task, log entry, and mention it yielded rather than tracked
+		// as a separate note-level entity (the index has no such concept
+		// yet; final dedup happens downstream in index.normalizeTags).
+		for i := range result.Tasks {
+			result.Tasks[i].Tags = append(result.Tasks[i].Tags, fmTags...)
+		}
+		for i := range result.LogEntries {
+			result.LogEntries[i].Tags = append(result.LogEntries[i].Tags, fmTags...)
+		}
+		for i := range result.Mentions {
+			result.Mentions[i].Tags = append(result.Mentions[i].Tags, fmTags...)
+		}
+	}
+
 	return result, nil
 }
 
@@ -137,6 +180,7 @@ func (p *RegexNoteParser) parseTaskLine(note *model.Note, line string, lineNumbe
 		CreatedAt: now,
 		UpdatedAt: now,
 		Line:      lineNumber,
+		Origin:    model.TaskOriginVault,
 	}
 	if status == model.TaskStatusDone && !now.IsZero() {
 		task.CompletedAt = &now
@@ -177,6 +221,29 @@ func (p *RegexNoteParser) parseLogLine(note *model.Note, line string, lineNumber
 	return entry, mentions
 }
 
+// parseWikiLinks extracts `[[wiki link]]` and `[[target|display]]`
+// references from a line. Target resolution against the vault happens
+// downstream in the index, so the returned links carry only the text as
+// written and a nil TargetNoteID.
+func (p *RegexNoteParser) parseWikiLinks(note *model.Note, line string, lineNumber int) []model.NoteLink {
+	matches := p.wikiLinkPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	links := make([]model.NoteLink, 0, len(matches))
+	for _, m := range matches {
+		target, display := splitWikiLink(m[1])
+		links = append(links, model.NoteLink{
+			SourceNoteID: note.ID,
+			SourceLine:   lineNumber,
+			TargetText:   target,
+			Display:      display,
+		})
+	}
+	return links
+}
+
 // parseMentionsFromLine extracts TaskMention objects from any `[T-xxxx]`
 // references contained on the line.
 func (p *RegexNoteParser) parseMentionsFromLine(line string, lineNumber int, tags []string, note *model.Note) []model.TaskMention {
@@ -234,28 +301,72 @@ func (p *RegexNoteParser) extractTimestamp(line string, fallback time.Time) (tim
 	return parsed, rest
 }
 
-// extractTags returns de-duplicated hashtag tokens from the provided input.
+// extractTags returns de-duplicated raw tag tokens from the provided input,
+// recognizing three flavors: bare "#hashtag", Bear-style "#multi word tags#"
+// (delimited by a matching closing "#"), and colon-delimited hierarchical
+// paths like ":project:yapper:". The raw tokens are passed through as-is;
+// index.normalizeTags/expandTag is what lowercases, collapses whitespace to
+// hyphens, and expands colon paths into their stored forms. Tags are
+// returned in the order they appear in input, regardless of flavor.
 func (p *RegexNoteParser) extractTags(input string) []string {
-	matches := p.tagPattern.FindAllStringSubmatch(input, -1)
-	if len(matches) == 0 {
-		return nil
+	input = maskInlineCode(input)
+	type found struct {
+		offset int
+		tag    string
 	}
-
-	tags := make([]string, 0, len(matches))
-	seen := make(map[string]struct{}, len(matches))
-	for _, m := range matches {
-		tag := m[1]
+	var matches []found
+	seen := make(map[string]struct{})
+	add := func(offset int, tag string) {
 		if _, ok := seen[tag]; ok {
-			continue
+			return
 		}
 		seen[tag] = struct{}{}
-		tags = append(tags, tag)
+		matches = append(matches, found{offset: offset, tag: tag})
 	}
-	return tags
+
+	// Bear-style tags are matched (and masked out, preserving byte offsets
+	// via space-replacement) first so their inner spaces don't also get
+	// picked up as separate bare hashtags.
+	for _, m := range p.bearTagPattern.FindAllStringSubmatchIndex(input, -1) {
+		add(m[0], input[m[2]:m[3]])
+	}
+	masked := maskByIndices(input, p.bearTagPattern.FindAllStringIndex(input, -1))
+
+	for _, m := range p.colonTagPattern.FindAllStringSubmatchIndex(masked, -1) {
+		add(m[0], masked[m[2]:m[3]])
+	}
+	masked = maskByIndices(masked, p.colonTagPattern.FindAllStringIndex(masked, -1))
+
+	for _, m := range p.tagPattern.FindAllStringSubmatchIndex(masked, -1) {
+		add(m[0], masked[m[2]:m[3]])
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].offset < matches[j].offset })
+	raw := make([]string, len(matches))
+	for i, m := range matches {
+		raw[i] = m.tag
+	}
+	return raw
+}
+
+// maskByIndices blanks out each [start, end) span in s with spaces,
+// preserving every other byte's offset for subsequent matching.
+func maskByIndices(s string, spans [][]int) string {
+	if len(spans) == 0 {
+		return s
+	}
+	b := []byte(s)
+	for _, span := range spans {
+		for i := span[0]; i < span[1]; i++ {
+			b[i] = ' '
+		}
+	}
+	return string(b)
 }
 
 // extractTaskIDs returns all task identifiers embedded in the line.
 func (p *RegexNoteParser) extractTaskIDs(line string) []model.TaskID {
+	line = maskInlineCode(line)
 	matches := p.taskIDPattern.FindAllStringSubmatch(line, -1)
 	if len(matches) == 0 {
 		return nil
@@ -277,6 +388,7 @@ func (p *RegexNoteParser) extractTaskIDs(line string) []model.TaskID {
 // extractExplicitTaskID returns the first task identifier declared inline on the
 // task content, if any.
 func (p *RegexNoteParser) extractExplicitTaskID(content string) model.TaskID {
+	content = maskInlineCode(content)
 	matches := p.taskIDPattern.FindStringSubmatch(content)
 	if matches == nil {
 		return ""
@@ -288,11 +400,27 @@ func (p *RegexNoteParser) extractExplicitTaskID(content string) model.TaskID {
 // and log entry content remain focused on the human-readable text.
 func (p *RegexNoteParser) cleanContent(content string) string {
 	withoutIDs := p.taskIDPattern.ReplaceAllString(content, "")
-	withoutTags := p.tagPattern.ReplaceAllString(withoutIDs, "")
+	withoutBear := p.bearTagPattern.ReplaceAllString(withoutIDs, "")
+	withoutColon := p.colonTagPattern.ReplaceAllString(withoutBear, "")
+	withoutTags := p.tagPattern.ReplaceAllString(withoutColon, "")
 	fields := strings.Fields(withoutTags)
 	return strings.Join(fields, " ")
 }
 
+// inlineCodePattern matches a `backtick span`, which cleanContent, extractTags,
+// and extractTaskIDs must never scan for tags or task IDs: a literal
+// "#define" or "[T-1]" inside a code span is source code, not a tag or a
+// mention.
+var inlineCodePattern = regexp.MustCompile("`[^`]*`")
+
+// maskInlineCode blanks out every inline code span in line so downstream
+// regex matching can't mistake code contents for tags or task IDs.
+func maskInlineCode(line string) string {
+	return inlineCodePattern.ReplaceAllStringFunc(line, func(span string) string {
+		return strings.Repeat(" ", len(span))
+	})
+}
+
 // parseTaskStatus maps task checkbox characters to a TaskStatus value.
 func parseTaskStatus(char string) model.TaskStatus {
 	switch strings.TrimSpace(strings.ToLower(char)) {
@@ -98,3 +98,146 @@ func TestRegexNoteParser_Parse(t *testing.T) {
 		t.Fatalf("unexpected mention: %+v", result.Mentions[1])
 	}
 }
+
+// TestRegexNoteParser_Parse_WikiLinks validates that [[wiki links]] are
+// extracted from anywhere in the note (not just the Tasks/Log sections) with
+// an unresolved target, leaving resolution to the index.
+func TestRegexNoteParser_Parse_WikiLinks(t *testing.T) {
+	content := strings.TrimSpace(`
+See [[Project Overview]] for context.
+## Log
+- Followed up with [[Jane Doe]] about billing
+- Filed under [[project overview|the overview doc]]
+`)
+
+	note := &model.Note{ID: "notes/daily.md", Path: "notes/daily.md", Title: "Daily", Content: content}
+
+	parser := NewRegexNoteParser()
+	result, err := parser.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(result.Links) != 3 {
+		t.Fatalf("expected 3 links, got %d: %+v", len(result.Links), result.Links)
+	}
+	if result.Links[0].TargetText != "Project Overview" || result.Links[0].Display != "Project Overview" || result.Links[0].SourceLine != 1 {
+		t.Fatalf("unexpected first link: %+v", result.Links[0])
+	}
+	if result.Links[0].TargetNoteID != nil {
+		t.Fatalf("expected unresolved target, got %+v", result.Links[0].TargetNoteID)
+	}
+	if result.Links[1].TargetText != "Jane Doe" || result.Links[1].SourceLine != 3 {
+		t.Fatalf("unexpected second link: %+v", result.Links[1])
+	}
+	if result.Links[2].TargetText != "project overview" || result.Links[2].Display != "the overview doc" {
+		t.Fatalf("unexpected third link: %+v", result.Links[2])
+	}
+}
+
+// TestRegexNoteParser_ExtractTags_MultiFlavor validates that bare hashtags,
+// Bear-style multi-word tags, and colon-delimited hierarchical tags are all
+// recognized, and that a Bear-style match doesn't also get picked up as a
+// separate bare hashtag.
+func TestRegexNoteParser_ExtractTags_MultiFlavor(t *testing.T) {
+	p := NewRegexNoteParser().(*RegexNoteParser)
+
+	tags := p.extractTags("Paid the #rent bill, filed under #multi word tags# and :project:yapper:")
+	want := []string{"rent", "multi word tags", "project:yapper"}
+	if len(tags) != len(want) {
+		t.Fatalf("expected %d tags, got %d: %+v", len(want), len(tags), tags)
+	}
+	for i, w := range want {
+		if tags[i] != w {
+			t.Fatalf("tag[%d] = %q, want %q (all: %+v)", i, tags[i], w, tags)
+		}
+	}
+}
+
+// TestRegexNoteParser_Parse_SkipsFencedCodeAndInlineCode validates that a
+// bullet-like line inside a fenced code block is never parsed as a task, and
+// that a `#tag`/`[T-*]`-looking token inside inline code is never extracted.
+func TestRegexNoteParser_Parse_SkipsFencedCodeAndInlineCode(t *testing.T) {
+	content := strings.TrimSpace("## Tasks\n```\n- [ ] not a real task [T-9999]\n```\n- [ ] Run `#define` correctly #work\n")
+
+	note := &model.Note{ID: "notes/fence.md", Path: "notes/fence.md", Title: "Fence", Content: content}
+
+	p := NewRegexNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(result.Tasks) != 1 {
+		t.Fatalf("expected 1 task (the fenced one skipped), got %+v", result.Tasks)
+	}
+	if len(result.Tasks[0].Tags) != 1 || result.Tasks[0].Tags[0] != "work" {
+		t.Fatalf("expected only the real #work tag, #define inside the code span must be ignored: %+v", result.Tasks[0].Tags)
+	}
+}
+
+// TestRegexNoteParser_Parse_Metadata validates that ParsedNote.Metadata
+// exposes the note's full decoded frontmatter, keys normalized to lower
+// case, separately from the tags merged onto individual tasks/logs/mentions.
+func TestRegexNoteParser_Parse_Metadata(t *testing.T) {
+	content := strings.TrimSpace(`
+---
+Status: draft
+tags: [finance]
+---
+## Tasks
+- [ ] Pay rent [T-1]
+`)
+
+	note := &model.Note{ID: "notes/rent.md", Path: "notes/rent.md", Title: "Rent", Content: content}
+
+	p := NewRegexNoteParser()
+	result, err := p.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if result.Metadata["status"] != "draft" {
+		t.Fatalf("expected metadata[status] = draft, got %+v", result.Metadata)
+	}
+}
+
+// TestRegexNoteParser_Parse_FrontmatterTags validates that tags declared in a
+// note's YAML frontmatter, under either "tags" or "keywords" and in either
+// scalar or sequence form, are merged onto every task, log entry, and mention
+// parsed from that note.
+func TestRegexNoteParser_Parse_FrontmatterTags(t *testing.T) {
+	content := strings.TrimSpace(`
+---
+tags: [finance, personal]
+keywords: urgent
+---
+## Tasks
+- [ ] Pay rent [T-1]
+`)
+
+	note := &model.Note{
+		ID:      "notes/rent.md",
+		Path:    "notes/rent.md",
+		Title:   "Rent",
+		Content: content,
+	}
+
+	parser := NewRegexNoteParser()
+	result, err := parser.Parse(context.Background(), note)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(result.Tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(result.Tasks))
+	}
+	gotTags := result.Tasks[0].Tags
+	wantTags := map[string]bool{"finance": true, "personal": true, "urgent": true}
+	if len(gotTags) != len(wantTags) {
+		t.Fatalf("expected %d tags on task, got %+v", len(wantTags), gotTags)
+	}
+	for _, tag := range gotTags {
+		if !wantTags[tag] {
+			t.Fatalf("unexpected frontmatter tag %q on task: %+v", tag, gotTags)
+		}
+	}
+}
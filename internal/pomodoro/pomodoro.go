@@ -0,0 +1,137 @@
+// Package pomodoro runs work/short-break/long-break cycles against a
+// model.Task, emitting state-change events on a channel a TUI can subscribe
+// to for rendering a countdown, and auto-creating a TimeEntry on the task
+// for each completed work interval.
+package pomodoro
+
+import (
+	"context"
+	"time"
+
+	model "github.com/weakphish/yapper/internal/taskmodel"
+)
+
+// Phase identifies which part of the cycle a Session is in.
+type Phase string
+
+const (
+	PhaseWork       Phase = "work"
+	PhaseShortBreak Phase = "short_break"
+	PhaseLongBreak  Phase = "long_break"
+)
+
+// Config controls the length of each phase and how often the long break
+// occurs. The zero value is not usable; use DefaultConfig.
+type Config struct {
+	Work           time.Duration
+	ShortBreak     time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int // take a long break after this many work intervals
+}
+
+// DefaultConfig is the classic 25/5/15, long break every 4th work interval.
+func DefaultConfig() Config {
+	return Config{
+		Work:           25 * time.Minute,
+		ShortBreak:     5 * time.Minute,
+		LongBreak:      15 * time.Minute,
+		LongBreakEvery: 4,
+	}
+}
+
+// Event reports a phase transition. Remaining counts down to zero over the
+// course of the phase named by Phase; a new Event is emitted once per
+// second while a phase is running, and once more when it ends.
+type Event struct {
+	Phase     Phase
+	Remaining time.Duration
+	// Done is true on the final event of a phase, the moment it completes.
+	Done bool
+}
+
+// Session runs cycles against a single Task.
+type Session struct {
+	task   *model.Task
+	cfg    Config
+	events chan Event
+
+	completedWork int
+}
+
+// New creates a Session for task using cfg. The returned Session does not
+// start running until Run is called.
+func New(task *model.Task, cfg Config) *Session {
+	return &Session{
+		task:   task,
+		cfg:    cfg,
+		events: make(chan Event, 1),
+	}
+}
+
+// Events returns the channel of phase updates. It is closed once Run
+// returns.
+func (s *Session) Events() <-chan Event {
+	return s.events
+}
+
+// Run drives the session through work/break phases until ctx is canceled,
+// starting with a work interval and alternating work with a break after
+// each one. Each completed work interval is recorded as a closed TimeEntry
+// on the task via StartTimer/StopTimer.
+func (s *Session) Run(ctx context.Context) error {
+	defer close(s.events)
+
+	working := true
+	for {
+		if working {
+			if _, err := s.task.StartTimer(); err != nil {
+				return err
+			}
+			if err := s.runPhase(ctx, PhaseWork, s.cfg.Work); err != nil {
+				s.task.StopTimer("")
+				return err
+			}
+			s.task.StopTimer("pomodoro")
+			s.completedWork++
+			working = false
+			continue
+		}
+
+		phase, dur := PhaseShortBreak, s.cfg.ShortBreak
+		if s.cfg.LongBreakEvery > 0 && s.completedWork%s.cfg.LongBreakEvery == 0 {
+			phase, dur = PhaseLongBreak, s.cfg.LongBreak
+		}
+		if err := s.runPhase(ctx, phase, dur); err != nil {
+			return err
+		}
+		working = true
+	}
+}
+
+func (s *Session) runPhase(ctx context.Context, phase Phase, dur time.Duration) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(dur)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			s.emit(ctx, Event{Phase: phase, Remaining: 0, Done: true})
+			return nil
+		}
+		s.emit(ctx, Event{Phase: phase, Remaining: remaining})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Session) emit(ctx context.Context, e Event) {
+	select {
+	case s.events <- e:
+	case <-ctx.Done():
+	}
+}
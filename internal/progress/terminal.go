@@ -0,0 +1,58 @@
+// Package progress renders core.ProgressReporter callbacks to a terminal.
+// It implements core.ProgressReporter structurally, so it doesn't need to
+// import the core package at all.
+package progress
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// TerminalReporter draws a live bar to stderr via schollz/progressbar,
+// showing the current file, rate, and ETA for a reindex.
+type TerminalReporter struct {
+	bar *progressbar.ProgressBar
+}
+
+// NewTerminalReporter constructs an idle reporter; the bar itself isn't
+// created until Start is called with the total note count.
+func NewTerminalReporter() *TerminalReporter {
+	return &TerminalReporter{}
+}
+
+func (t *TerminalReporter) Start(total int) {
+	t.bar = progressbar.NewOptions(total,
+		progressbar.OptionSetDescription("reindexing vault"),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionShowCount(),
+		progressbar.OptionShowIts(),
+		progressbar.OptionSetPredictTime(true),
+	)
+}
+
+func (t *TerminalReporter) Step(path string) {
+	if t.bar == nil {
+		return
+	}
+	t.bar.Describe(fmt.Sprintf("reindexing %s", path))
+	_ = t.bar.Add(1)
+}
+
+func (t *TerminalReporter) Finish(err error) {
+	if t.bar == nil {
+		return
+	}
+	_ = t.bar.Finish()
+}
+
+// IsTTY reports whether f is attached to a terminal, the condition under
+// which a TerminalReporter is worth using over a silent no-op.
+func IsTTY(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
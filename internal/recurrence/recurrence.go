@@ -0,0 +1,181 @@
+// Package recurrence parses a limited subset of RFC 5545 RRULE strings
+// (FREQ, INTERVAL, BYDAY, COUNT, UNTIL) and computes occurrence dates from
+// them, so recurring tasks can be scheduled without a full calendaring
+// dependency.
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Freq is an RRULE FREQ value. Only the four yapper schedules on are
+// supported; anything else is a parse error.
+type Freq string
+
+const (
+	FreqDaily   Freq = "DAILY"
+	FreqWeekly  Freq = "WEEKLY"
+	FreqMonthly Freq = "MONTHLY"
+	FreqYearly  Freq = "YEARLY"
+)
+
+// untilLayout is RRULE's compact UTC timestamp form for the UNTIL field.
+const untilLayout = "20060102T150405Z"
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Rule is a parsed RRULE, limited to the fields yapper needs. Count is
+// parsed but not enforced by NextOccurrence, since counting occurrences
+// requires tracking how many have already fired (e.g. on the owning Task),
+// which is outside a single stateless NextOccurrence call; a caller that
+// cares about Count must stop requesting further occurrences itself once it
+// reaches the limit.
+type Rule struct {
+	Freq     Freq
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    time.Time
+}
+
+// Parse decodes an RRULE string, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR".
+func Parse(rrule string) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(rrule, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("recurrence: malformed rule part %q", part)
+		}
+		key, value := strings.ToUpper(kv[0]), kv[1]
+		switch key {
+		case "FREQ":
+			switch Freq(strings.ToUpper(value)) {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				rule.Freq = Freq(strings.ToUpper(value))
+			default:
+				return nil, fmt.Errorf("recurrence: unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("recurrence: invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				wd, ok := weekdayCodes[strings.ToUpper(strings.TrimSpace(code))]
+				if !ok {
+					return nil, fmt.Errorf("recurrence: invalid BYDAY %q", code)
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("recurrence: invalid COUNT %q", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, value)
+			if err != nil {
+				return nil, fmt.Errorf("recurrence: invalid UNTIL %q: %w", value, err)
+			}
+			rule.Until = until
+		default:
+			// Unrecognized RRULE parts (BYMONTHDAY, BYSETPOS, WKST, ...) are
+			// ignored rather than rejected, so a rule imported from a richer
+			// external calendar doesn't fail to parse outright just because
+			// yapper only ever schedules on these five fields.
+		}
+	}
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("recurrence: rule is missing FREQ")
+	}
+	return rule, nil
+}
+
+// NextOccurrence returns the first occurrence of r strictly after t, and
+// false if r's UNTIL bound has already passed.
+func (r *Rule) NextOccurrence(after time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		candidate := r.step(after)
+		if !r.withinUntil(candidate) {
+			return time.Time{}, false
+		}
+		return candidate, true
+	}
+
+	// With BYDAY set, occurrences land on any matching weekday within an
+	// "active" week, where weeks are counted in Interval-sized groups
+	// starting from the week containing after. Walking a day at a time from
+	// after+1 (rather than jumping a full Interval of weeks first, which
+	// would skip every remaining matching weekday in after's own week) finds
+	// every occurrence RFC 5545 expects, e.g. BYDAY=MO,WE,FR firing three
+	// times a week rather than once. Bounded to just over a year of weeks so
+	// a malformed rule can't loop forever.
+	// Interval only groups weeks for FREQ=WEEKLY; BYDAY combined with any
+	// other FREQ (unusual, but not rejected by Parse) matches every week.
+	weekInterval := 1
+	if r.Freq == FreqWeekly {
+		weekInterval = r.Interval
+	}
+
+	anchorWeek := weekStart(after)
+	candidate := after.AddDate(0, 0, 1)
+	for i := 0; i < 366*weekInterval; i++ {
+		weeksSince := int(weekStart(candidate).Sub(anchorWeek).Hours() / 24 / 7)
+		if weeksSince%weekInterval == 0 && containsWeekday(r.ByDay, candidate.Weekday()) {
+			if !r.withinUntil(candidate) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+		candidate = candidate.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+// weekStart returns midnight on the Monday beginning t's calendar week.
+func weekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	year, month, day := t.AddDate(0, 0, -daysSinceMonday).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func (r *Rule) step(t time.Time) time.Time {
+	switch r.Freq {
+	case FreqDaily:
+		return t.AddDate(0, 0, r.Interval)
+	case FreqWeekly:
+		return t.AddDate(0, 0, 7*r.Interval)
+	case FreqMonthly:
+		return t.AddDate(0, r.Interval, 0)
+	case FreqYearly:
+		return t.AddDate(r.Interval, 0, 0)
+	default:
+		return t
+	}
+}
+
+func (r *Rule) withinUntil(t time.Time) bool {
+	return r.Until.IsZero() || !t.After(r.Until)
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, rrule string) *Rule {
+	t.Helper()
+	rule, err := Parse(rrule)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", rrule, err)
+	}
+	return rule
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNextOccurrenceDaily(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY;INTERVAL=3")
+	got, ok := rule.NextOccurrence(date(2024, time.January, 1))
+	if !ok {
+		t.Fatal("NextOccurrence() returned false, want true")
+	}
+	if want := date(2024, time.January, 4); !got.Equal(want) {
+		t.Fatalf("NextOccurrence() = %v, want %v", got, want)
+	}
+}
+
+func TestNextOccurrenceWeeklyNoByDay(t *testing.T) {
+	rule := mustParse(t, "FREQ=WEEKLY")
+	got, ok := rule.NextOccurrence(date(2024, time.January, 1)) // a Monday
+	if !ok {
+		t.Fatal("NextOccurrence() returned false, want true")
+	}
+	if want := date(2024, time.January, 8); !got.Equal(want) {
+		t.Fatalf("NextOccurrence() = %v, want %v", got, want)
+	}
+}
+
+// TestNextOccurrenceWeeklyByDayFiresEveryMatchingDay is the regression test
+// for a bug where a full 7*Interval day jump happened before the BYDAY scan,
+// skipping every remaining matching weekday in the current week.
+func TestNextOccurrenceWeeklyByDayFiresEveryMatchingDay(t *testing.T) {
+	rule := mustParse(t, "FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	monday := date(2024, time.January, 1)
+
+	wed, ok := rule.NextOccurrence(monday)
+	if !ok {
+		t.Fatal("NextOccurrence(monday) returned false, want true")
+	}
+	if want := date(2024, time.January, 3); !wed.Equal(want) {
+		t.Fatalf("NextOccurrence(monday) = %v, want %v (this week's Wednesday)", wed, want)
+	}
+
+	fri, ok := rule.NextOccurrence(wed)
+	if !ok {
+		t.Fatal("NextOccurrence(wed) returned false, want true")
+	}
+	if want := date(2024, time.January, 5); !fri.Equal(want) {
+		t.Fatalf("NextOccurrence(wed) = %v, want %v (this week's Friday)", fri, want)
+	}
+
+	nextMon, ok := rule.NextOccurrence(fri)
+	if !ok {
+		t.Fatal("NextOccurrence(fri) returned false, want true")
+	}
+	if want := date(2024, time.January, 8); !nextMon.Equal(want) {
+		t.Fatalf("NextOccurrence(fri) = %v, want %v (next week's Monday)", nextMon, want)
+	}
+}
+
+// TestNextOccurrenceWeeklyByDayWithIntervalSkipsWeeks confirms a BYDAY rule
+// with INTERVAL > 1 only fires in every Interval-th week, not every week.
+func TestNextOccurrenceWeeklyByDayWithIntervalSkipsWeeks(t *testing.T) {
+	rule := mustParse(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR")
+	monday := date(2024, time.January, 1)
+
+	fri, ok := rule.NextOccurrence(monday)
+	if !ok {
+		t.Fatal("NextOccurrence(monday) returned false, want true")
+	}
+	if want := date(2024, time.January, 5); !fri.Equal(want) {
+		t.Fatalf("NextOccurrence(monday) = %v, want %v (this active week's Friday)", fri, want)
+	}
+
+	// The following week (Jan 8-12) is inactive; the next occurrence should
+	// skip straight to the Monday two weeks out.
+	next, ok := rule.NextOccurrence(fri)
+	if !ok {
+		t.Fatal("NextOccurrence(fri) returned false, want true")
+	}
+	if want := date(2024, time.January, 15); !next.Equal(want) {
+		t.Fatalf("NextOccurrence(fri) = %v, want %v (next active week's Monday)", next, want)
+	}
+}
+
+func TestNextOccurrenceMonthly(t *testing.T) {
+	rule := mustParse(t, "FREQ=MONTHLY")
+	got, ok := rule.NextOccurrence(date(2024, time.January, 31))
+	if !ok {
+		t.Fatal("NextOccurrence() returned false, want true")
+	}
+	if want := date(2024, time.March, 2); !got.Equal(want) {
+		t.Fatalf("NextOccurrence() = %v, want %v", got, want)
+	}
+}
+
+func TestNextOccurrenceRespectsUntil(t *testing.T) {
+	rule := mustParse(t, "FREQ=DAILY;UNTIL=20240102T000000Z")
+	if _, ok := rule.NextOccurrence(date(2024, time.January, 2)); ok {
+		t.Fatal("NextOccurrence() returned true past UNTIL, want false")
+	}
+}
+
+func TestParseRejectsUnsupportedFreq(t *testing.T) {
+	if _, err := Parse("FREQ=HOURLY"); err == nil {
+		t.Fatal("Parse() succeeded for an unsupported FREQ, want error")
+	}
+}
+
+func TestParseRejectsMissingFreq(t *testing.T) {
+	if _, err := Parse("INTERVAL=2"); err == nil {
+		t.Fatal("Parse() succeeded with no FREQ, want error")
+	}
+}
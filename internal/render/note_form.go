@@ -4,7 +4,8 @@ import (
 	"log/slog"
 
 	"github.com/charmbracelet/huh"
-	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/adapter/fzf"
+	model "github.com/weakphish/yapper/internal/taskmodel"
 	"gorm.io/gorm"
 )
 
@@ -32,22 +33,33 @@ func AddNoteForm(title string, db *gorm.DB) model.Note {
 
 	slog.Debug("Created list of taskOptions for depends", "taskOptions", taskOptions)
 
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().Value(&title).Title("Title"),
-			huh.NewInput().Value(&content).Title("Content"),
-			huh.NewMultiSelect[string]().
-				Title("Depends On").
-				Options(taskOptions...).
-				Value(&relatedToTitles),
-		),
-	)
+	useFzf := fzf.Available() && len(allTasks) > 0
 
+	fields := []huh.Field{
+		huh.NewInput().Value(&title).Title("Title"),
+		huh.NewInput().Value(&content).Title("Content"),
+	}
+	if !useFzf {
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title("Depends On").
+			Options(taskOptions...).
+			Value(&relatedToTitles))
+	}
+
+	form := huh.NewForm(huh.NewGroup(fields...))
 	err := form.Run()
 	if err != nil {
 		slog.Error("Error running task form", "error", err)
 	}
 
+	if useFzf {
+		picked, err := pickRelatedTasks(allTasks)
+		if err != nil {
+			slog.Error("Error running fzf depends-on picker", "error", err)
+		}
+		relatedToTitles = picked
+	}
+
 	// get the ID of the task that it depends on and put it as the dependent
 	var relatedTasks []model.Task
 	db.Where("title IN ?", relatedToTitles).Find(&relatedTasks)
@@ -60,3 +72,17 @@ func AddNoteForm(title string, db *gorm.DB) model.Note {
 
 	return note
 }
+
+// pickRelatedTasks replaces huh's "Depends On" multi-select with fzf's
+// --multi mode (Tab to toggle) when fzf is on $PATH.
+func pickRelatedTasks(allTasks []model.Task) ([]string, error) {
+	candidates := make([]fzf.Candidate, len(allTasks))
+	for i, task := range allTasks {
+		candidates[i] = fzf.Candidate{
+			Key:     task.Title,
+			Label:   task.Title,
+			Preview: task.Description,
+		}
+	}
+	return fzf.PickMulti("Depends On", candidates)
+}
@@ -4,7 +4,9 @@ import (
 	"log/slog"
 
 	"github.com/charmbracelet/huh"
-	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/adapter/fzf"
+	"github.com/weakphish/yapper/internal/graph"
+	model "github.com/weakphish/yapper/internal/taskmodel"
 	"gorm.io/gorm"
 )
 
@@ -33,41 +35,73 @@ func AddTaskForm(title string, db *gorm.DB) model.Task {
 
 	slog.Info("Created list of taskOptions for depends", "taskOptions", taskOptions)
 
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().Value(&title).Title("Title"),
-			huh.NewInput().Value(&description).Title("Description"),
-			huh.NewSelect[model.TaskStatus]().
-				Title("Status").
-				Options(
-					huh.NewOption("Todo", model.Todo),
-					huh.NewOption("In Progress", model.InProgress),
-					huh.NewOption("Completed", model.Completed),
-				).
-				Value(&status),
-			huh.NewSelect[string]().
-				Title("Depends On").
-				Options(taskOptions...).
-				Value(&dependsOnTitle),
-		),
-	)
+	useFzf := fzf.Available() && len(allTasks) > 0
+
+	fields := []huh.Field{
+		huh.NewInput().Value(&title).Title("Title"),
+		huh.NewInput().Value(&description).Title("Description"),
+		huh.NewSelect[model.TaskStatus]().
+			Title("Status").
+			Options(
+				huh.NewOption("Todo", model.Todo),
+				huh.NewOption("In Progress", model.InProgress),
+				huh.NewOption("Completed", model.Completed),
+			).
+			Value(&status),
+	}
+	if !useFzf {
+		fields = append(fields, huh.NewSelect[string]().
+			Title("Depends On").
+			Options(taskOptions...).
+			Value(&dependsOnTitle))
+	}
 
+	form := huh.NewForm(huh.NewGroup(fields...))
 	err := form.Run()
 	if err != nil {
 		slog.Error("Error running task form", "error", err)
 	}
 
-	// get the ID of the task that it depends on and put it as the dependent
-	var dependsOnTask model.Task
-	db.Where(&model.Task{Title: dependsOnTitle}).Find(&dependsOnTask)
+	if useFzf {
+		dependsOnTitle, err = pickDependsOn(allTasks)
+		if err != nil {
+			slog.Error("Error running fzf depends-on picker", "error", err)
+		}
+	}
 
 	task := model.Task{
 		Title:       title,
 		Description: description,
 		Status:      status,
-		DependsOn:   &dependsOnTask,
-		DependsOnID: dependsOnTask.ID,
+	}
+
+	// get the ID of the task that it depends on and attach it, unless doing
+	// so would close a dependency cycle
+	if dependsOnTitle != "" {
+		var dependsOnTask model.Task
+		db.Preload("DependsOn").Where(&model.Task{Title: dependsOnTitle}).Find(&dependsOnTask)
+
+		if graph.WouldCreateCycle(&task, &dependsOnTask) {
+			slog.Error("Refusing to add dependency: it would create a cycle", "task", task.Title, "dependsOn", dependsOnTask.Title)
+		} else {
+			task.DependsOn = []*model.Task{&dependsOnTask}
+		}
 	}
 
 	return task
 }
+
+// pickDependsOn replaces huh's "Depends On" select with an fzf picker when
+// fzf is on $PATH, since a terminal fuzzy-find is far faster than scrolling
+// a huh.Select once a vault accumulates more than a handful of tasks.
+func pickDependsOn(allTasks []model.Task) (string, error) {
+	candidates := make([]fzf.Candidate, len(allTasks))
+	for i, task := range allTasks {
+		candidates[i] = fzf.Candidate{
+			Key:     task.Title,
+			Label:   task.Title,
+			Preview: task.Description,
+		}
+	}
+	return fzf.Pick("Depends On", candidates)
+}
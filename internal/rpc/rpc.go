@@ -23,6 +23,21 @@ type Response struct {
 	Error   *ErrorBody      `json:"error,omitempty"`
 }
 
+// Notification models a server-pushed JSON-RPC 2.0 message: it carries no
+// `id` and therefore never expects a reply. Used for `note/changed` and
+// `task/changed` pushes to long-lived clients that subscribed via
+// `note/subscribeChanges`.
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// NewNotification builds a server-push notification for the given method.
+func NewNotification(method string, params interface{}) Notification {
+	return Notification{JSONRPC: "2.0", Method: method, Params: params}
+}
+
 // ErrorBody matches the JSON-RPC error object.
 type ErrorBody struct {
 	Code    int    `json:"code"`
@@ -148,7 +163,14 @@ func ServerError(message string) Error {
 
 // Parameter payloads ---------------------------------------------------------
 
+// NotebookParams is the minimal params shape for methods that only need to
+// select which notebook to operate on.
+type NotebookParams struct {
+	Notebook string `json:"notebook,omitempty"`
+}
+
 type ListTasksParams struct {
+	Notebook     string           `json:"notebook,omitempty"`
 	Status       *core.TaskStatus `json:"status"`
 	Tags         []string         `json:"tags"`
 	TextSearch   *string          `json:"text_search"`
@@ -156,27 +178,71 @@ type ListTasksParams struct {
 }
 
 type TaskDetailParams struct {
-	TaskID string `json:"task_id"`
+	Notebook string `json:"notebook,omitempty"`
+	TaskID   string `json:"task_id"`
 }
 
 type TagParams struct {
-	Tag string `json:"tag"`
+	Notebook string `json:"notebook,omitempty"`
+	Tag      string `json:"tag"`
+}
+
+type SearchLogParams struct {
+	Notebook string `json:"notebook,omitempty"`
+	Query    string `json:"query"`
+}
+
+// FeedParams bounds the activity window for the core.feed RPC method.
+type FeedParams struct {
+	Notebook string `json:"notebook,omitempty"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
 }
 
 type RangeParams struct {
-	Start string `json:"start"`
-	End   string `json:"end"`
+	Notebook string `json:"notebook,omitempty"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
 }
 
 type OpenDailyParams struct {
-	Date string `json:"date"`
+	Notebook string `json:"notebook,omitempty"`
+	Date     string `json:"date"`
 }
 
 type NoteParams struct {
-	NoteID string `json:"note_id"`
+	Notebook string `json:"notebook,omitempty"`
+	NoteID   string `json:"note_id"`
 }
 
 type WriteNoteParams struct {
-	NoteID  string `json:"note_id"`
-	Content string `json:"content"`
+	Notebook string `json:"notebook,omitempty"`
+	NoteID   string `json:"note_id"`
+	Content  string `json:"content"`
+}
+
+type NewNoteParams struct {
+	Notebook  string                 `json:"notebook,omitempty"`
+	Template  string                 `json:"template"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// WorkspaceOpenParams names and locates a notebook to add to the running
+// daemon's WorkspaceRegistry.
+type WorkspaceOpenParams struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// NoteChangedParams is the payload of a `note/changed` push notification.
+type NoteChangedParams struct {
+	NoteID string `json:"note_id"`
+	Kind   string `json:"kind"` // "created" | "modified" | "removed"
+}
+
+// TaskChangedParams is the payload of a `task/changed` push notification.
+type TaskChangedParams struct {
+	TaskID string `json:"task_id"`
+	Status string `json:"status,omitempty"`
+	Kind   string `json:"kind"` // "created" | "modified" | "removed"
 }
@@ -0,0 +1,69 @@
+// Package scheduler periodically surfaces recurring tasks whose next
+// occurrence has come due: a Task.Complete spawns the next occurrence
+// up-front with a future CreatedAt, and this package is what notices once
+// that date has arrived.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/weakphish/yapper/internal/db"
+	model "github.com/weakphish/yapper/internal/taskmodel"
+	"gorm.io/gorm"
+)
+
+// OnDue is called once for each task whose occurrence has just become due.
+type OnDue func(*model.Task)
+
+// Run polls the task database every interval until ctx is canceled, calling
+// onDue for every Todo task whose CreatedAt has reached the present and
+// hasn't been surfaced yet. "Already surfaced" is tracked in memory by task
+// ID, so restarting Run re-surfaces anything still due rather than losing
+// track of it.
+//
+// It opens the database once, up front, and reuses that connection for every
+// poll rather than calling db.InitDB on each tick, which would otherwise
+// leak a fresh connection pool every interval.
+func Run(ctx context.Context, interval time.Duration, onDue OnDue) {
+	d, err := db.InitDB()
+	if err != nil {
+		slog.Error("scheduler: could not open database", "error", err)
+		return
+	}
+
+	seen := make(map[string]struct{})
+	poll(d, seen, onDue)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll(d, seen, onDue)
+		}
+	}
+}
+
+func poll(d *gorm.DB, seen map[string]struct{}, onDue OnDue) {
+	var tasks []*model.Task
+	if result := d.Where("status = ?", model.Todo).Find(&tasks); result.Error != nil {
+		slog.Error("scheduler: could not list tasks", "error", result.Error)
+		return
+	}
+
+	now := time.Now()
+	for _, t := range tasks {
+		if _, already := seen[t.ID]; already {
+			continue
+		}
+		if t.CreatedAt.After(now) {
+			continue
+		}
+		seen[t.ID] = struct{}{}
+		onDue(t)
+	}
+}
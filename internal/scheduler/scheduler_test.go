@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	model "github.com/weakphish/yapper/internal/taskmodel"
+	"gorm.io/gorm"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	d, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	if err := d.AutoMigrate(&model.Task{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return d
+}
+
+func TestPollSurfacesDueTaskOnce(t *testing.T) {
+	d := openTestDB(t)
+
+	due := &model.Task{ID: "due-1", Status: model.Todo, CreatedAt: time.Now().Add(-time.Hour)}
+	future := &model.Task{ID: "future-1", Status: model.Todo, CreatedAt: time.Now().Add(time.Hour)}
+	if result := d.Create(due); result.Error != nil {
+		t.Fatalf("seed due task: %v", result.Error)
+	}
+	if result := d.Create(future); result.Error != nil {
+		t.Fatalf("seed future task: %v", result.Error)
+	}
+
+	seen := make(map[string]struct{})
+	var surfaced []string
+	onDue := func(task *model.Task) { surfaced = append(surfaced, task.ID) }
+
+	poll(d, seen, onDue)
+	if len(surfaced) != 1 || surfaced[0] != "due-1" {
+		t.Fatalf("poll surfaced %v, want only due-1", surfaced)
+	}
+
+	// Polling again must not re-surface a task already seen.
+	poll(d, seen, onDue)
+	if len(surfaced) != 1 {
+		t.Fatalf("second poll surfaced %v, want no new tasks", surfaced)
+	}
+}
+
+func TestPollReusesSameConnection(t *testing.T) {
+	d := openTestDB(t)
+	seen := make(map[string]struct{})
+
+	poll(d, seen, func(*model.Task) {})
+	sqlDB, err := d.DB()
+	if err != nil {
+		t.Fatalf("underlying sql.DB: %v", err)
+	}
+	before := sqlDB.Stats().OpenConnections
+
+	poll(d, seen, func(*model.Task) {})
+	after := sqlDB.Stats().OpenConnections
+	if after > before {
+		t.Fatalf("poll opened a new connection: before=%d after=%d", before, after)
+	}
+}
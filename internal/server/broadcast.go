@@ -0,0 +1,72 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/jack/yapper/go-note/internal/rpc"
+)
+
+// Broadcaster fans server-push notifications (note/changed, task/changed)
+// out to the single stdio client when it has subscribed via
+// note/subscribeChanges. Notifications published before any subscription,
+// or after an unsubscribe, are dropped.
+type Broadcaster struct {
+	mu         sync.Mutex
+	subscribed bool
+	out        chan rpc.Notification
+}
+
+// NewBroadcaster constructs an unsubscribed Broadcaster.
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{out: make(chan rpc.Notification, 64)}
+}
+
+// Subscribe marks the client as wanting push notifications.
+func (b *Broadcaster) Subscribe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribed = true
+}
+
+// Unsubscribe stops delivery of further notifications.
+func (b *Broadcaster) Unsubscribe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribed = false
+}
+
+// Publish queues n for delivery if a client is currently subscribed. It
+// never blocks: a full queue drops the oldest-pending notification rather
+// than stalling the caller (typically a watcher goroutine).
+func (b *Broadcaster) Publish(n rpc.Notification) {
+	b.mu.Lock()
+	subscribed := b.subscribed
+	b.mu.Unlock()
+	if !subscribed {
+		return
+	}
+	b.enqueue(n)
+}
+
+// PublishNow queues n regardless of subscription state. It's for
+// notifications tied to the in-flight request that sent them (e.g.
+// $/progress during a core.reindex call), which the caller expects to see
+// whether or not it separately subscribed to note/task change events.
+func (b *Broadcaster) PublishNow(n rpc.Notification) {
+	b.enqueue(n)
+}
+
+func (b *Broadcaster) enqueue(n rpc.Notification) {
+	select {
+	case b.out <- n:
+	default:
+		select {
+		case <-b.out:
+		default:
+		}
+		select {
+		case b.out <- n:
+		default:
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import "github.com/jack/yapper/go-note/internal/rpc"
+
+// RPCReporter streams `$/progress` notifications over broadcaster for a
+// reindex triggered by a JSON-RPC call, so the client can render a
+// percentage instead of blocking blind until the response arrives. It
+// implements core.ProgressReporter structurally.
+type RPCReporter struct {
+	broadcaster *Broadcaster
+	total       int
+	done        int
+}
+
+// NewRPCReporter builds a reporter that publishes through broadcaster.
+func NewRPCReporter(broadcaster *Broadcaster) *RPCReporter {
+	return &RPCReporter{broadcaster: broadcaster}
+}
+
+func (r *RPCReporter) Start(total int) {
+	r.total = total
+	r.publish("started", "")
+}
+
+func (r *RPCReporter) Step(path string) {
+	r.done++
+	r.publish("progress", path)
+}
+
+func (r *RPCReporter) Finish(err error) {
+	status := "finished"
+	if err != nil {
+		status = "error"
+	}
+	r.publish(status, "")
+}
+
+func (r *RPCReporter) publish(status, path string) {
+	percent := 0
+	if r.total > 0 {
+		percent = r.done * 100 / r.total
+	}
+	r.broadcaster.PublishNow(rpc.NewNotification("$/progress", map[string]interface{}{
+		"status":  status,
+		"path":    path,
+		"done":    r.done,
+		"total":   r.total,
+		"percent": percent,
+	}))
+}
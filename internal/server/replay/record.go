@@ -0,0 +1,80 @@
+// Package replay records the stdio JSON-RPC traffic exchanged by
+// server.Run to a structured log, and can later feed a recorded log's
+// client-originated frames back through a dispatcher to check that the
+// daemon still reproduces the same responses.
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Direction tags which side of the wire a Record came from.
+type Direction string
+
+const (
+	DirClientToServer Direction = "client→server"
+	DirServerToClient Direction = "server→client"
+	DirError          Direction = "error"
+)
+
+// Record is one newline-delimited JSON line in a recording.
+type Record struct {
+	Seq       int             `json:"seq"`
+	Timestamp time.Time       `json:"timestamp"`
+	Direction Direction       `json:"direction"`
+	Payload   json.RawMessage `json:"payload"`
+	// ElapsedMS is the handler duration for server→client/error records;
+	// zero for client→server ones.
+	ElapsedMS int64 `json:"elapsed_ms,omitempty"`
+}
+
+// Recorder appends Records to an underlying writer as they occur. It is
+// safe for concurrent use since Broadcaster-driven pushes and the main
+// request/response loop may record from different goroutines.
+type Recorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+	seq int
+}
+
+// NewRecorder wraps w (typically an os.File opened for the session) so
+// every subsequent Client/Server/Error call appends one JSON line.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, enc: json.NewEncoder(w)}
+}
+
+// Client records a frame read from the client before it's dispatched.
+func (r *Recorder) Client(payload json.RawMessage) {
+	r.write(DirClientToServer, payload, 0)
+}
+
+// Server records a frame written back to the client, along with how long
+// the handler took to produce it.
+func (r *Recorder) Server(payload json.RawMessage, elapsed time.Duration) {
+	r.write(DirServerToClient, payload, elapsed)
+}
+
+// Error records a frame that failed before a normal response could be
+// produced (e.g. malformed JSON).
+func (r *Recorder) Error(payload json.RawMessage, elapsed time.Duration) {
+	r.write(DirError, payload, elapsed)
+}
+
+func (r *Recorder) write(dir Direction, payload json.RawMessage, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	rec := Record{
+		Seq:       r.seq,
+		Timestamp: time.Now(),
+		Direction: dir,
+		Payload:   append(json.RawMessage(nil), payload...),
+		ElapsedMS: elapsed.Milliseconds(),
+	}
+	// Best-effort: a recording failure shouldn't take down the daemon.
+	_ = r.enc.Encode(rec)
+}
@@ -0,0 +1,181 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/rpc"
+	"github.com/jack/yapper/go-note/internal/server"
+)
+
+// Speed controls how a Replayer paces requests relative to the original
+// recording.
+type Speed int
+
+const (
+	// SpeedFast fires every request back-to-back.
+	SpeedFast Speed = iota
+	// SpeedRealtime sleeps between requests to reproduce the original
+	// inter-request delays observed in the recording.
+	SpeedRealtime
+)
+
+// Options configures a replay run.
+type Options struct {
+	Speed Speed
+	// OnlyMethod, when non-empty, skips every recorded request whose
+	// method doesn't match.
+	OnlyMethod string
+}
+
+// Mismatch describes one recorded response that didn't reproduce.
+type Mismatch struct {
+	Seq      int
+	Method   string
+	Expected json.RawMessage
+	Actual   json.RawMessage
+}
+
+// Summary reports the outcome of a replay run.
+type Summary struct {
+	Matched    int
+	Mismatched []Mismatch
+	Skipped    int
+}
+
+// OK reports whether every replayed request reproduced its recorded
+// response.
+func (s Summary) OK() bool {
+	return len(s.Mismatched) == 0
+}
+
+// Replay reads newline-delimited Records from r, feeds every
+// client→server frame into server.HandleRequest against registry, and
+// compares the result with the server→client frame that followed it in
+// the recording. Fields named in maskFields are stripped from both sides
+// before comparing, so volatile values (e.g. timestamps) don't cause false
+// mismatches.
+func Replay(r io.Reader, registry *core.WorkspaceRegistry, opts Options, maskFields []string) (Summary, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var summary Summary
+	var pending *rpc.Request
+	var pendingMethod string
+	var lastTimestamp time.Time
+
+	flushPending := func() {
+		if pending == nil {
+			return
+		}
+		summary.Skipped++
+		pending = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return summary, fmt.Errorf("decoding record %d: %w", summary.Matched+summary.Skipped+len(summary.Mismatched)+1, err)
+		}
+
+		switch rec.Direction {
+		case DirClientToServer:
+			flushPending()
+
+			var req rpc.Request
+			if err := json.Unmarshal(rec.Payload, &req); err != nil {
+				return summary, fmt.Errorf("decoding request in record %d: %w", rec.Seq, err)
+			}
+			if opts.OnlyMethod != "" && req.Method != opts.OnlyMethod {
+				summary.Skipped++
+				continue
+			}
+			if opts.Speed == SpeedRealtime && !lastTimestamp.IsZero() {
+				if gap := rec.Timestamp.Sub(lastTimestamp); gap > 0 {
+					time.Sleep(gap)
+				}
+			}
+			lastTimestamp = rec.Timestamp
+			pending = &req
+			pendingMethod = req.Method
+
+		case DirServerToClient:
+			if pending == nil {
+				continue
+			}
+			resp, _ := server.HandleRequest(registry, nil, nil, *pending)
+			actual, err := json.Marshal(resp)
+			if err != nil {
+				return summary, fmt.Errorf("marshaling replayed response for record %d: %w", rec.Seq, err)
+			}
+			if maskedEqual(rec.Payload, actual, maskFields) {
+				summary.Matched++
+			} else {
+				summary.Mismatched = append(summary.Mismatched, Mismatch{
+					Seq:      rec.Seq,
+					Method:   pendingMethod,
+					Expected: rec.Payload,
+					Actual:   actual,
+				})
+			}
+			pending = nil
+
+		case DirError:
+			pending = nil
+		}
+	}
+	flushPending()
+
+	if err := scanner.Err(); err != nil {
+		return summary, fmt.Errorf("reading recording: %w", err)
+	}
+	return summary, nil
+}
+
+// maskedEqual compares two JSON payloads for equality after deleting any
+// top-level-or-nested object key named in fields from both sides.
+func maskedEqual(expected, actual json.RawMessage, fields []string) bool {
+	a, errA := maskedCanonical(expected, fields)
+	b, errB := maskedCanonical(actual, fields)
+	if errA != nil || errB != nil {
+		return string(expected) == string(actual)
+	}
+	return a == b
+}
+
+func maskedCanonical(payload json.RawMessage, fields []string) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return "", err
+	}
+	mask(value, fields)
+	canon, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(canon), nil
+}
+
+func mask(value interface{}, fields []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, f := range fields {
+			delete(v, f)
+		}
+		for _, child := range v {
+			mask(child, fields)
+		}
+	case []interface{}:
+		for _, child := range v {
+			mask(child, fields)
+		}
+	}
+}
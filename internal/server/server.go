@@ -6,36 +6,84 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/feed"
 	"github.com/jack/yapper/go-note/internal/logging"
 	"github.com/jack/yapper/go-note/internal/rpc"
 )
 
-// Run launches the blocking stdio JSON-RPC loop.
-func Run(domain *core.Domain) error {
+// WorkspaceFactory opens a notebook rooted at path and returns the Domain
+// that should back it. cmd/note-daemon supplies one that wires up the same
+// Vault/IndexStore/parser combination as the process's other notebooks.
+type WorkspaceFactory func(path string) (*core.Domain, error)
+
+// Recorder captures every frame that crosses the stdio boundary, for later
+// replay (see internal/server/replay.Recorder, which satisfies this
+// structurally without server needing to import that package). nil
+// disables recording.
+type Recorder interface {
+	Client(payload json.RawMessage)
+	Server(payload json.RawMessage, elapsed time.Duration)
+	Error(payload json.RawMessage, elapsed time.Duration)
+}
+
+// batchConcurrency bounds how many requests inside a single JSON-RPC batch
+// are dispatched at once, so one heavy call (e.g. core.reindex) in a batch
+// can't stall the lighter reads sharing it.
+const batchConcurrency = 8
+
+// Run launches the blocking stdio JSON-RPC loop over registry. broadcaster
+// may be nil, in which case note/subscribeChanges is rejected as
+// unsupported. open may be nil, in which case workspace/open is rejected.
+// rec may be nil, in which case traffic is not recorded.
+func Run(registry *core.WorkspaceRegistry, broadcaster *Broadcaster, open WorkspaceFactory, rec Recorder) error {
 	scanner := bufio.NewScanner(os.Stdin)
 	writer := bufio.NewWriter(os.Stdout)
+	var writeMu sync.Mutex
+
+	if broadcaster != nil {
+		go func() {
+			for notification := range broadcaster.out {
+				writeMu.Lock()
+				_ = writeNotification(writer, notification)
+				writeMu.Unlock()
+			}
+		}()
+	}
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if line == "" {
 			continue
 		}
+		if rec != nil {
+			rec.Client(json.RawMessage(line))
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if err := handleBatch(registry, broadcaster, open, rec, writer, &writeMu, []byte(line)); err != nil {
+				return err
+			}
+			continue
+		}
 
 		var request rpc.Request
 		if err := json.Unmarshal([]byte(line), &request); err != nil {
 			logging.Warnf("malformed JSON: %v", err)
 			resp := rpc.ResponseError(rpc.NullID(), rpc.ParseError(err.Error()))
-			if err := writeResponse(writer, resp); err != nil {
+			if err := writeLockedResponse(writer, &writeMu, rec, resp, 0); err != nil {
 				return err
 			}
 			continue
 		}
 
-		resp, ok := handleRequest(domain, request)
+		start := time.Now()
+		resp, ok := HandleRequest(registry, broadcaster, open, request)
 		if ok {
-			if err := writeResponse(writer, resp); err != nil {
+			if err := writeLockedResponse(writer, &writeMu, rec, resp, time.Since(start)); err != nil {
 				return err
 			}
 		}
@@ -48,6 +96,103 @@ func Run(domain *core.Domain) error {
 	return nil
 }
 
+// handleBatch decodes a JSON-RPC batch (a top-level JSON array of request
+// objects) and dispatches its elements concurrently, bounded by
+// batchConcurrency. Per the JSON-RPC 2.0 spec, the non-notification
+// responses are collected and written back as a single JSON array in the
+// same order as the batch; a batch consisting entirely of notifications
+// writes nothing at all.
+func handleBatch(registry *core.WorkspaceRegistry, broadcaster *Broadcaster, open WorkspaceFactory, rec Recorder, writer *bufio.Writer, writeMu *sync.Mutex, raw []byte) error {
+	var requests []rpc.Request
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		logging.Warnf("malformed JSON batch: %v", err)
+		resp := rpc.ResponseError(rpc.NullID(), rpc.ParseError(err.Error()))
+		return writeLockedResponse(writer, writeMu, rec, resp, 0)
+	}
+	if len(requests) == 0 {
+		resp := rpc.ResponseError(rpc.NullID(), rpc.InvalidRequest("batch must contain at least one request"))
+		return writeLockedResponse(writer, writeMu, rec, resp, 0)
+	}
+
+	type outcome struct {
+		resp rpc.Response
+		ok   bool
+	}
+	outcomes := make([]outcome, len(requests))
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		wg.Add(1)
+		go func(i int, req rpc.Request) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			start := time.Now()
+			resp, ok := HandleRequest(registry, broadcaster, open, req)
+			if ok && rec != nil {
+				if payload, err := json.Marshal(resp); err == nil {
+					rec.Server(payload, time.Since(start))
+				}
+			}
+			outcomes[i] = outcome{resp: resp, ok: ok}
+		}(i, req)
+	}
+	wg.Wait()
+
+	var responses []rpc.Response
+	for _, o := range outcomes {
+		if o.ok {
+			responses = append(responses, o.resp)
+		}
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	payload, err := json.Marshal(responses)
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(payload); err != nil {
+		return err
+	}
+	if err := writer.WriteByte('\n'); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// writeLockedResponse serializes a single response write (and its optional
+// recording) behind writeMu, so it can be shared by the single-request path
+// and handleBatch's error paths without interleaving with batch writes.
+func writeLockedResponse(writer *bufio.Writer, writeMu *sync.Mutex, rec Recorder, resp rpc.Response, elapsed time.Duration) error {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+	if rec != nil {
+		if payload, err := json.Marshal(resp); err == nil {
+			rec.Server(payload, elapsed)
+		}
+	}
+	return writeResponse(writer, resp)
+}
+
+// paramsSnippet renders req.Params for a warn-level log line, truncated so a
+// large payload doesn't flood the log.
+func paramsSnippet(params json.RawMessage) string {
+	const maxLen = 200
+	s := strings.TrimSpace(string(params))
+	if s == "" {
+		s = "{}"
+	}
+	if len(s) > maxLen {
+		return s[:maxLen] + "…"
+	}
+	return s
+}
+
 func writeResponse(w *bufio.Writer, resp rpc.Response) error {
 	payload, err := json.Marshal(resp)
 	if err != nil {
@@ -62,7 +207,25 @@ func writeResponse(w *bufio.Writer, resp rpc.Response) error {
 	return w.Flush()
 }
 
-func handleRequest(domain *core.Domain, req rpc.Request) (rpc.Response, bool) {
+func writeNotification(w *bufio.Writer, n rpc.Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	if err := w.WriteByte('\n'); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// HandleRequest decodes and dispatches a single JSON-RPC request, returning
+// the response to write (if any — notifications produce none) and whether
+// ok is true. It's exported so internal/server/replay can re-drive recorded
+// requests against a live registry without duplicating dispatch logic.
+func HandleRequest(registry *core.WorkspaceRegistry, broadcaster *Broadcaster, open WorkspaceFactory, req rpc.Request) (rpc.Response, bool) {
 	id := rpc.NullID()
 	if req.ID != nil {
 		id = *req.ID
@@ -72,10 +235,10 @@ func handleRequest(domain *core.Domain, req rpc.Request) (rpc.Response, bool) {
 		return rpc.ResponseError(id, rpc.InvalidRequest("jsonrpc must be \"2.0\"")), true
 	}
 
-	result, err := dispatch(domain, req.Method, req.Params)
+	result, err := dispatch(registry, broadcaster, open, req.Method, req.Params)
 	if err.Code != 0 {
 		if req.ID == nil {
-			logging.Warnf("notification for method %q failed: %v", req.Method, err)
+			logging.Warnf("notification for method %q failed: %v (params: %s)", req.Method, err, paramsSnippet(req.Params))
 			return rpc.Response{}, false
 		}
 		return rpc.ResponseError(id, err), true
@@ -87,10 +250,68 @@ func handleRequest(domain *core.Domain, req rpc.Request) (rpc.Response, bool) {
 	return rpc.ResponseResult(id, result), true
 }
 
-func dispatch(domain *core.Domain, method string, params json.RawMessage) (interface{}, rpc.Error) {
+// resolveDomain looks up the notebook named by notebook (or the default
+// notebook, if notebook is empty) and returns its Domain.
+func resolveDomain(registry *core.WorkspaceRegistry, notebook string) (*core.Domain, rpc.Error) {
+	ws, err := registry.Get(notebook)
+	if err != nil {
+		return nil, rpc.InvalidRequest(err.Error())
+	}
+	return ws.Domain, rpc.Error{}
+}
+
+func dispatch(registry *core.WorkspaceRegistry, broadcaster *Broadcaster, open WorkspaceFactory, method string, params json.RawMessage) (interface{}, rpc.Error) {
 	switch method {
+	case "workspace/list":
+		return map[string]interface{}{
+			"notebooks": registry.List(),
+			"default":   registry.DefaultName(),
+		}, rpc.Error{}
+	case "workspace/open":
+		if open == nil {
+			return nil, rpc.ServerError("this server was started without support for opening notebooks at runtime")
+		}
+		payload, err := rpc.ParseParams[rpc.WorkspaceOpenParams](params)
+		if err.Code != 0 {
+			return nil, err
+		}
+		if payload.Name == "" || payload.Path == "" {
+			return nil, rpc.InvalidParams("both name and path are required")
+		}
+		domain, openErr := open(payload.Path)
+		if openErr != nil {
+			return nil, rpc.ServerError(openErr.Error())
+		}
+		registry.Open(payload.Name, domain)
+		return map[string]string{"status": "opened", "name": payload.Name}, rpc.Error{}
+	case "note/subscribeChanges":
+		if broadcaster == nil {
+			return nil, rpc.ServerError("this server was started without change notifications enabled")
+		}
+		broadcaster.Subscribe()
+		return map[string]string{"status": "subscribed"}, rpc.Error{}
+	case "note/unsubscribeChanges":
+		if broadcaster == nil {
+			return nil, rpc.ServerError("this server was started without change notifications enabled")
+		}
+		broadcaster.Unsubscribe()
+		return map[string]string{"status": "unsubscribed"}, rpc.Error{}
 	case "core.reindex":
-		if err := domain.ReindexAll(); err != nil {
+		payload, err := rpc.ParseParams[rpc.NotebookParams](params)
+		if err.Code != 0 {
+			return nil, err
+		}
+		domain, err := resolveDomain(registry, payload.Notebook)
+		if err.Code != 0 {
+			return nil, err
+		}
+		// The daemon always performs a full scan once at startup; subsequent
+		// calls prefer the cheaper incremental path when the index supports it.
+		var reporter core.ProgressReporter = core.NoOpProgress{}
+		if broadcaster != nil {
+			reporter = NewRPCReporter(broadcaster)
+		}
+		if err := domain.ReindexIncrementalWithProgress(reporter); err != nil {
 			return nil, rpc.ServerError(err.Error())
 		}
 		return map[string]string{"status": "ok"}, rpc.Error{}
@@ -99,6 +320,10 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		filter := &core.TaskFilter{}
 		if payload.Status != nil {
 			filter.Status = payload.Status
@@ -122,6 +347,10 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		taskID := core.TaskID(payload.TaskID)
 		task, mentions, ok := domain.TaskDetail(taskID)
 		if !ok {
@@ -138,12 +367,52 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		return domain.ItemsForTag(payload.Tag), rpc.Error{}
+	case "core.search_logs":
+		payload, err := rpc.ParseParams[rpc.SearchLogParams](params)
+		if err.Code != 0 {
+			return nil, err
+		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
+		return domain.SearchLogEntries(payload.Query), rpc.Error{}
+	case "core.feed":
+		payload, err := rpc.ParseParams[rpc.FeedParams](params)
+		if err.Code != 0 {
+			return nil, err
+		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
+		rangeSel, err2 := rpc.ParseRange(payload.Start, payload.End)
+		if err2.Code != 0 {
+			return nil, err2
+		}
+		notebook := payload.Notebook
+		if notebook == "" {
+			notebook = registry.DefaultName()
+		}
+		xmlDoc, feedErr := feed.Build(domain, rangeSel.Start, rangeSel.End, notebook, fmt.Sprintf("urn:yapper:notebook:%s", notebook))
+		if feedErr != nil {
+			return nil, rpc.ServerError(feedErr.Error())
+		}
+		return map[string]string{"feed": xmlDoc}, rpc.Error{}
 	case "core.notes_in_range":
 		payload, err := rpc.ParseParams[rpc.RangeParams](params)
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		rangeSel, err2 := rpc.ParseRange(payload.Start, payload.End)
 		if err2.Code != 0 {
 			return nil, err2
@@ -154,6 +423,10 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		rangeSel, err2 := rpc.ParseRange(payload.Start, payload.End)
 		if err2.Code != 0 {
 			return nil, err2
@@ -164,6 +437,10 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		date, err2 := rpc.ParseDate(payload.Date)
 		if err2.Code != 0 {
 			return nil, err2
@@ -178,15 +455,37 @@ func dispatch(domain *core.Domain, method string, params json.RawMessage) (inter
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		if note, ok := domain.ReadNote(core.NoteID(payload.NoteID)); ok {
 			return note, rpc.Error{}
 		}
 		return nil, rpc.InvalidRequest("note not found")
+	case "newNote":
+		payload, err := rpc.ParseParams[rpc.NewNoteParams](params)
+		if err.Code != 0 {
+			return nil, err
+		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
+		note, noteErr := domain.NewNoteFromTemplate(payload.Template, payload.Variables)
+		if noteErr != nil {
+			return nil, rpc.ServerError(noteErr.Error())
+		}
+		return note, rpc.Error{}
 	case "core.write_note":
 		payload, err := rpc.ParseParams[rpc.WriteNoteParams](params)
 		if err.Code != 0 {
 			return nil, err
 		}
+		domain, derr := resolveDomain(registry, payload.Notebook)
+		if derr.Code != 0 {
+			return nil, derr
+		}
 		note, writeErr := domain.WriteNote(core.NoteID(payload.NoteID), payload.Content)
 		if writeErr != nil {
 			return nil, rpc.ServerError(writeErr.Error())
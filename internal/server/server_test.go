@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jack/yapper/go-note/internal/core"
+	"github.com/jack/yapper/go-note/internal/rpc"
+)
+
+func rawID(n int) *json.RawMessage {
+	raw := json.RawMessage(fmt.Sprintf("%d", n))
+	return &raw
+}
+
+// newTestWriter returns a buffered writer over an in-memory buffer, matching
+// the writer/mutex pair handleBatch expects, so a test can inspect exactly
+// what was written without going through stdio.
+func newTestWriter() (*bufio.Writer, *sync.Mutex, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return bufio.NewWriter(buf), &sync.Mutex{}, buf
+}
+
+func TestHandleRequestUnknownMethodReturnsError(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	req := rpc.Request{JSONRPC: "2.0", ID: rawID(1), Method: "core.does_not_exist"}
+
+	resp, ok := HandleRequest(registry, nil, nil, req)
+	if !ok {
+		t.Fatal("HandleRequest() ok = false, want true for a request with an id")
+	}
+	if resp.Error == nil {
+		t.Fatal("HandleRequest().Error = nil, want a method-not-found error")
+	}
+	if resp.Error.Code != int(rpc.CodeMethodNotFound) {
+		t.Fatalf("HandleRequest().Error.Code = %d, want %d", resp.Error.Code, rpc.CodeMethodNotFound)
+	}
+}
+
+// TestHandleRequestNotificationProducesNoResponse confirms a request with no
+// id (a notification) never produces a response to write back, even when its
+// dispatch fails, per JSON-RPC 2.0 semantics.
+func TestHandleRequestNotificationProducesNoResponse(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	req := rpc.Request{JSONRPC: "2.0", Method: "core.does_not_exist"}
+
+	resp, ok := HandleRequest(registry, nil, nil, req)
+	if ok {
+		t.Fatalf("HandleRequest() ok = true, want false for a notification; resp = %+v", resp)
+	}
+}
+
+func TestHandleRequestWorkspaceList(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	req := rpc.Request{JSONRPC: "2.0", ID: rawID(1), Method: "workspace/list"}
+
+	resp, ok := HandleRequest(registry, nil, nil, req)
+	if !ok {
+		t.Fatal("HandleRequest() ok = false, want true")
+	}
+	if resp.Error != nil {
+		t.Fatalf("HandleRequest().Error = %+v, want nil", resp.Error)
+	}
+}
+
+func TestHandleBatchRejectsEmptyBatch(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	writer, writeMu, buf := newTestWriter()
+
+	if err := handleBatch(registry, nil, nil, nil, writer, writeMu, []byte("[]")); err != nil {
+		t.Fatalf("handleBatch() error = %v", err)
+	}
+
+	var resp rpc.Response
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v (body %q)", err, buf.String())
+	}
+	if resp.Error == nil || resp.Error.Code != int(rpc.CodeInvalidRequest) {
+		t.Fatalf("handleBatch() wrote %+v, want an invalid-request error", resp)
+	}
+}
+
+// TestHandleBatchAllNotificationsWritesNothing confirms a batch made up
+// entirely of notifications (no ids) never writes a response array at all,
+// per JSON-RPC 2.0 batch semantics.
+func TestHandleBatchAllNotificationsWritesNothing(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	writer, writeMu, buf := newTestWriter()
+
+	batch := `[{"jsonrpc":"2.0","method":"core.does_not_exist"},{"jsonrpc":"2.0","method":"core.does_not_exist"}]`
+	if err := handleBatch(registry, nil, nil, nil, writer, writeMu, []byte(batch)); err != nil {
+		t.Fatalf("handleBatch() error = %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Fatalf("handleBatch() wrote %q, want nothing for an all-notification batch", buf.String())
+	}
+}
+
+// TestHandleBatchPreservesRequestOrder dispatches a batch of several
+// requests concurrently and confirms the written response array still
+// matches the batch's original order, not completion order.
+func TestHandleBatchPreservesRequestOrder(t *testing.T) {
+	registry := core.NewWorkspaceRegistry("default")
+	writer, writeMu, buf := newTestWriter()
+
+	const n = 20
+	var batch []rpc.Request
+	for i := 0; i < n; i++ {
+		batch = append(batch, rpc.Request{JSONRPC: "2.0", ID: rawID(i), Method: "workspace/list"})
+	}
+	raw, err := json.Marshal(batch)
+	if err != nil {
+		t.Fatalf("marshal batch: %v", err)
+	}
+
+	if err := handleBatch(registry, nil, nil, nil, writer, writeMu, raw); err != nil {
+		t.Fatalf("handleBatch() error = %v", err)
+	}
+
+	var responses []rpc.Response
+	if err := json.Unmarshal(buf.Bytes(), &responses); err != nil {
+		t.Fatalf("unmarshal responses: %v (body %q)", err, buf.String())
+	}
+	if len(responses) != n {
+		t.Fatalf("handleBatch() wrote %d responses, want %d", len(responses), n)
+	}
+	for i, resp := range responses {
+		if string(resp.ID) != fmt.Sprintf("%d", i) {
+			t.Fatalf("responses[%d].ID = %s, want %d (order not preserved)", i, resp.ID, i)
+		}
+	}
+}
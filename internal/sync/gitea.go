@@ -0,0 +1,236 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// GiteaConfig configures a GiteaTaskRepository.
+type GiteaConfig struct {
+	// RemoteName identifies this repository for Task.Origin
+	// ("remote:<RemoteName>") and is passed to Syncer as the repository's
+	// Name().
+	RemoteName string
+	BaseURL    string // e.g. "https://gitea.example.com"
+	Token      string // personal/app access token
+	Owner      string
+	Repo       string
+}
+
+// GiteaTaskRepository implements RemoteTaskRepository by mapping tasks onto
+// issues in a Gitea or Forgejo repository (the two share the same issues
+// API), the same way IMAPTaskRepository maps them onto mailbox messages: a
+// task's Title becomes the issue title, its Status becomes the issue's
+// open/closed state, and a fetched task's ID is the issue number formatted
+// as a string, so a remote-origin task round-trips without a side table.
+//
+// A vault-origin task being published for the first time has no such
+// numeric ID yet, so Publish embeds taskIDMarker plus the task's own ID in
+// the issue body (the same role IMAP's custom headers play) and searches
+// for it before creating, so a task already backed by an issue gets edited
+// in place instead of getting a duplicate issue on every Push.
+type GiteaTaskRepository struct {
+	cfg    GiteaConfig
+	client *gitea.Client
+}
+
+// NewGiteaTaskRepository validates cfg and constructs a ready-to-use
+// GiteaTaskRepository.
+func NewGiteaTaskRepository(cfg GiteaConfig) (*GiteaTaskRepository, error) {
+	if cfg.RemoteName == "" {
+		return nil, errors.New("gitea remote name is required")
+	}
+	if cfg.BaseURL == "" {
+		return nil, errors.New("gitea base url is required")
+	}
+	if cfg.Owner == "" || cfg.Repo == "" {
+		return nil, errors.New("gitea owner and repo are required")
+	}
+	client, err := gitea.NewClient(cfg.BaseURL, gitea.SetToken(cfg.Token))
+	if err != nil {
+		return nil, fmt.Errorf("create gitea client for %q: %w", cfg.BaseURL, err)
+	}
+	return &GiteaTaskRepository{cfg: cfg, client: client}, nil
+}
+
+// Name implements RemoteTaskRepository.
+func (r *GiteaTaskRepository) Name() string {
+	return r.cfg.RemoteName
+}
+
+// Fetch implements RemoteTaskRepository by listing every issue updated since
+// the given time. Gitea has no issue-deletion API available to a normal
+// token, so closing an issue is as close as this backend gets to a
+// tombstone; Fetch never returns any, and a task deleted remotely instead
+// shows up here as Done.
+func (r *GiteaTaskRepository) Fetch(ctx context.Context, since time.Time) ([]model.Task, []TaskTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	opts := gitea.ListIssueOption{
+		Type:  gitea.IssueTypeIssue,
+		State: gitea.StateAll,
+	}
+	if !since.IsZero() {
+		opts.Since = since
+	}
+
+	issues, _, err := r.client.ListRepoIssues(r.cfg.Owner, r.cfg.Repo, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list issues for %s/%s: %w", r.cfg.Owner, r.cfg.Repo, err)
+	}
+
+	tasks := make([]model.Task, 0, len(issues))
+	for _, issue := range issues {
+		tasks = append(tasks, issueToTask(r.cfg.RemoteName, issue))
+	}
+	return tasks, nil, nil
+}
+
+// Publish implements RemoteTaskRepository by creating an issue for every
+// task that isn't already backed by one (its ID isn't a known issue number)
+// and editing the issue in place for every task that is. A tombstoned task
+// closes its issue rather than deleting it, per Fetch's doc comment.
+func (r *GiteaTaskRepository) Publish(ctx context.Context, changes []TaskChange) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if change.Tombstone != nil {
+			index, ok := issueIndex(change.Tombstone.TaskID)
+			if !ok {
+				continue // never had a backing issue; nothing to close
+			}
+			closed := gitea.StateClosed
+			if _, _, err := r.client.EditIssue(r.cfg.Owner, r.cfg.Repo, index, gitea.EditIssueOption{State: &closed}); err != nil {
+				return fmt.Errorf("close issue %d for task %q: %w", index, change.Tombstone.TaskID, err)
+			}
+			continue
+		}
+
+		task := change.Task
+		index, ok := issueIndex(task.ID)
+		if !ok {
+			found, err := r.findBackingIssue(task.ID)
+			if err != nil {
+				return err
+			}
+			index, ok = found, found != 0
+		}
+
+		body := issueBody(task)
+		if ok {
+			state := stateFromStatus(task.Status)
+			opt := gitea.EditIssueOption{Title: task.Title, Body: &body, State: &state}
+			if _, _, err := r.client.EditIssue(r.cfg.Owner, r.cfg.Repo, index, opt); err != nil {
+				return fmt.Errorf("edit issue %d for task %q: %w", index, task.ID, err)
+			}
+			continue
+		}
+
+		opt := gitea.CreateIssueOption{Title: task.Title, Body: body, Closed: task.Status == model.TaskStatusDone}
+		if _, _, err := r.client.CreateIssue(r.cfg.Owner, r.cfg.Repo, opt); err != nil {
+			return fmt.Errorf("create issue for task %q: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+// taskIDMarker precedes a vault-origin task's own ID in an issue's body, so
+// findBackingIssue can recognize an issue it already created for that task
+// even though the task's ID (a vault ID, not an issue number) never changes
+// to match the issue it's backed by.
+const taskIDMarker = "Yapper-Task-ID:"
+
+// issueBody renders an issue body for task: its title, followed by the
+// marker findBackingIssue searches for.
+func issueBody(task model.Task) string {
+	return fmt.Sprintf("%s\n\n%s %s", task.Title, taskIDMarker, task.ID)
+}
+
+// taskIDFromBody extracts the vault task ID embedded by issueBody, if any.
+func taskIDFromBody(body string) (model.TaskID, bool) {
+	idx := strings.LastIndex(body, taskIDMarker)
+	if idx < 0 {
+		return "", false
+	}
+	id := strings.TrimSpace(body[idx+len(taskIDMarker):])
+	if id == "" {
+		return "", false
+	}
+	return model.TaskID(id), true
+}
+
+// findBackingIssue searches for an issue already carrying id's marker in its
+// body, returning its index, or 0 if none exists yet. Gitea's issue search
+// only supports a keyword full-text match, so results are still checked
+// against the exact marker before being trusted.
+func (r *GiteaTaskRepository) findBackingIssue(id model.TaskID) (int64, error) {
+	opts := gitea.ListIssueOption{
+		Type:    gitea.IssueTypeIssue,
+		State:   gitea.StateAll,
+		KeyWord: string(id),
+	}
+	issues, _, err := r.client.ListRepoIssues(r.cfg.Owner, r.cfg.Repo, opts)
+	if err != nil {
+		return 0, fmt.Errorf("search issues for task %q: %w", id, err)
+	}
+	for _, issue := range issues {
+		if found, ok := taskIDFromBody(issue.Body); ok && found == id {
+			return issue.Index, nil
+		}
+	}
+	return 0, nil
+}
+
+// issueIndex parses a task ID as a Gitea issue number. A task whose ID isn't
+// purely numeric (a vault task that has never been published before) has no
+// corresponding issue yet.
+func issueIndex(id model.TaskID) (int64, bool) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// issueToTask maps a fetched Gitea issue onto a task, the remote's side of
+// the Title/Status mapping Publish performs in the other direction.
+func issueToTask(remoteName string, issue *gitea.Issue) model.Task {
+	return model.Task{
+		ID:        model.TaskID(strconv.FormatInt(issue.Index, 10)),
+		Title:     issue.Title,
+		Status:    statusFromState(issue.State),
+		CreatedAt: issue.Created,
+		UpdatedAt: issue.Updated,
+		Origin:    model.RemoteTaskOrigin(remoteName),
+	}
+}
+
+func statusFromState(state gitea.StateType) model.TaskStatus {
+	if state == gitea.StateClosed {
+		return model.TaskStatusDone
+	}
+	return model.TaskStatusTodo
+}
+
+func stateFromStatus(status model.TaskStatus) gitea.StateType {
+	if status == model.TaskStatusDone {
+		return gitea.StateClosed
+	}
+	return gitea.StateOpen
+}
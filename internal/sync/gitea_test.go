@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/weakphish/yapper/internal/model"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+func TestNewGiteaTaskRepositoryRejectsIncompleteConfig(t *testing.T) {
+	base := GiteaConfig{RemoteName: "work-gitea", BaseURL: "https://gitea.example.com", Owner: "alice", Repo: "notes"}
+
+	missingName := base
+	missingName.RemoteName = ""
+	if _, err := NewGiteaTaskRepository(missingName); err == nil {
+		t.Fatal("NewGiteaTaskRepository() with no RemoteName succeeded, want error")
+	}
+
+	missingURL := base
+	missingURL.BaseURL = ""
+	if _, err := NewGiteaTaskRepository(missingURL); err == nil {
+		t.Fatal("NewGiteaTaskRepository() with no BaseURL succeeded, want error")
+	}
+
+	missingOwner := base
+	missingOwner.Owner = ""
+	if _, err := NewGiteaTaskRepository(missingOwner); err == nil {
+		t.Fatal("NewGiteaTaskRepository() with no Owner succeeded, want error")
+	}
+
+	missingRepo := base
+	missingRepo.Repo = ""
+	if _, err := NewGiteaTaskRepository(missingRepo); err == nil {
+		t.Fatal("NewGiteaTaskRepository() with no Repo succeeded, want error")
+	}
+}
+
+func TestIssueBodyRoundTripsTaskID(t *testing.T) {
+	task := model.Task{ID: "vault-task-42", Title: "Ship the report"}
+
+	body := issueBody(task)
+	got, ok := taskIDFromBody(body)
+	if !ok {
+		t.Fatalf("taskIDFromBody(%q) ok = false, want true", body)
+	}
+	if got != task.ID {
+		t.Fatalf("taskIDFromBody(%q) = %q, want %q", body, got, task.ID)
+	}
+}
+
+func TestTaskIDFromBodyNoMarker(t *testing.T) {
+	if _, ok := taskIDFromBody("just a plain issue body"); ok {
+		t.Fatal("taskIDFromBody() found a marker in a body that has none")
+	}
+}
+
+func TestTaskIDFromBodyEmptyID(t *testing.T) {
+	body := taskIDMarker + "   "
+	if _, ok := taskIDFromBody(body); ok {
+		t.Fatal("taskIDFromBody() accepted a marker with no ID after it")
+	}
+}
+
+func TestTaskIDFromBodyUsesLastMarker(t *testing.T) {
+	// Guards against a naive first-match search picking up the word
+	// "Yapper-Task-ID:" if it ever appeared earlier in a user-authored body.
+	body := "See also " + taskIDMarker + " not-the-id\n\n" + taskIDMarker + " real-id"
+	got, ok := taskIDFromBody(body)
+	if !ok {
+		t.Fatalf("taskIDFromBody(%q) ok = false, want true", body)
+	}
+	if got != "real-id" {
+		t.Fatalf("taskIDFromBody(%q) = %q, want %q", body, got, "real-id")
+	}
+}
+
+func TestIssueIndexParsesNumericID(t *testing.T) {
+	index, ok := issueIndex("42")
+	if !ok || index != 42 {
+		t.Fatalf("issueIndex(\"42\") = (%d, %v), want (42, true)", index, ok)
+	}
+}
+
+func TestIssueIndexRejectsVaultID(t *testing.T) {
+	if _, ok := issueIndex("vault-task-42"); ok {
+		t.Fatal("issueIndex() accepted a non-numeric vault task ID")
+	}
+}
+
+func TestStatusFromState(t *testing.T) {
+	if got := statusFromState(gitea.StateClosed); got != model.TaskStatusDone {
+		t.Fatalf("statusFromState(closed) = %v, want Done", got)
+	}
+	if got := statusFromState(gitea.StateOpen); got != model.TaskStatusTodo {
+		t.Fatalf("statusFromState(open) = %v, want Todo", got)
+	}
+}
+
+func TestStateFromStatus(t *testing.T) {
+	if got := stateFromStatus(model.TaskStatusDone); got != gitea.StateClosed {
+		t.Fatalf("stateFromStatus(Done) = %v, want closed", got)
+	}
+	if got := stateFromStatus(model.TaskStatusTodo); got != gitea.StateOpen {
+		t.Fatalf("stateFromStatus(Todo) = %v, want open", got)
+	}
+}
+
+func TestIssueToTask(t *testing.T) {
+	issue := &gitea.Issue{Index: 7, Title: "Ship the report", State: gitea.StateOpen}
+	task := issueToTask("work-gitea", issue)
+
+	if task.ID != "7" {
+		t.Fatalf("issueToTask().ID = %q, want %q", task.ID, "7")
+	}
+	if task.Title != issue.Title {
+		t.Fatalf("issueToTask().Title = %q, want %q", task.Title, issue.Title)
+	}
+	if task.Status != model.TaskStatusTodo {
+		t.Fatalf("issueToTask().Status = %v, want Todo", task.Status)
+	}
+	if task.Origin != model.RemoteTaskOrigin("work-gitea") {
+		t.Fatalf("issueToTask().Origin = %q, want %q", task.Origin, "work-gitea")
+	}
+}
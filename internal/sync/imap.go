@@ -0,0 +1,341 @@
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+
+	"github.com/weakphish/yapper/internal/model"
+)
+
+// Custom message headers an IMAPTaskRepository uses to round-trip a task's
+// metadata; the body carries its Markdown description.
+const (
+	headerTaskID    = "X-Yapper-Task-Id"
+	headerUpdatedAt = "X-Yapper-Updated-At"
+	headerStatus    = "X-Yapper-Status"
+	headerTags      = "X-Yapper-Tags"
+	headerDeleted   = "X-Yapper-Deleted"
+)
+
+// IMAPConfig configures an IMAPTaskRepository.
+type IMAPConfig struct {
+	// RemoteName identifies this repository for Task.Origin
+	// ("remote:<RemoteName>") and is passed to Syncer as the repository's
+	// Name().
+	RemoteName string
+	Addr       string // host:port, e.g. "imap.example.com:993"
+	Username   string
+	Password   string
+	Mailbox    string // folder tasks are stored in, e.g. "Yapper/Tasks"
+}
+
+// IMAPTaskRepository implements RemoteTaskRepository by serializing each
+// task as a message in a configured IMAP folder, in the spirit of gte's
+// IMAP-backed task store: custom headers carry TaskID/UpdatedAt/Status/tags,
+// and the message body holds the task's Markdown description. It dials a
+// fresh connection per Fetch/Publish call rather than holding one open,
+// since sync round-trips are infrequent and this keeps connection lifecycle
+// trivial to reason about.
+type IMAPTaskRepository struct {
+	cfg IMAPConfig
+}
+
+// NewIMAPTaskRepository validates cfg and constructs a ready-to-use
+// IMAPTaskRepository.
+func NewIMAPTaskRepository(cfg IMAPConfig) (*IMAPTaskRepository, error) {
+	if cfg.RemoteName == "" {
+		return nil, errors.New("imap remote name is required")
+	}
+	if cfg.Addr == "" {
+		return nil, errors.New("imap address is required")
+	}
+	if cfg.Mailbox == "" {
+		return nil, errors.New("imap mailbox is required")
+	}
+	return &IMAPTaskRepository{cfg: cfg}, nil
+}
+
+// Name implements RemoteTaskRepository.
+func (r *IMAPTaskRepository) Name() string {
+	return r.cfg.RemoteName
+}
+
+func (r *IMAPTaskRepository) connect() (*client.Client, error) {
+	c, err := client.DialTLS(r.cfg.Addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dial imap %q: %w", r.cfg.Addr, err)
+	}
+	if err := c.Login(r.cfg.Username, r.cfg.Password); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("login to imap %q: %w", r.cfg.Addr, err)
+	}
+	if _, err := c.Select(r.cfg.Mailbox, false); err != nil {
+		c.Logout()
+		return nil, fmt.Errorf("select mailbox %q: %w", r.cfg.Mailbox, err)
+	}
+	return c, nil
+}
+
+// Fetch implements RemoteTaskRepository by searching the configured mailbox
+// for messages received since the given time and decoding each into a task
+// or tombstone.
+func (r *IMAPTaskRepository) Fetch(ctx context.Context, since time.Time) ([]model.Task, []TaskTombstone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	c, err := r.connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer c.Logout()
+
+	criteria := imap.NewSearchCriteria()
+	if !since.IsZero() {
+		// IMAP SINCE only has day granularity, so this may re-return
+		// messages from earlier the same day; reconcileRemote's UpdatedAt
+		// comparison already treats anything not actually newer as a no-op.
+		criteria.Since = since
+	}
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return nil, nil, fmt.Errorf("search mailbox %q: %w", r.cfg.Mailbox, err)
+	}
+	if len(uids) == 0 {
+		return nil, nil, nil
+	}
+
+	section := &imap.BodySectionName{}
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	messages := make(chan *imap.Message, len(uids))
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqset, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, messages)
+	}()
+
+	var tasks []model.Task
+	var tombstones []TaskTombstone
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		task, tombstone, deleted, err := parseTaskMessage(r.cfg.RemoteName, msg.Uid, body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if deleted {
+			tombstones = append(tombstones, tombstone)
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	if err := <-done; err != nil {
+		return nil, nil, fmt.Errorf("fetch mailbox %q: %w", r.cfg.Mailbox, err)
+	}
+	return tasks, tombstones, nil
+}
+
+// Publish implements RemoteTaskRepository by appending one message per
+// change and, for changes that replace an existing message, marking the old
+// copy \Deleted (then expunging) once last-writer-wins has confirmed the new
+// change is no older than what's already there.
+func (r *IMAPTaskRepository) Publish(ctx context.Context, changes []TaskChange) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	c, err := r.connect()
+	if err != nil {
+		return err
+	}
+	defer c.Logout()
+
+	var staleUIDs []uint32
+	for _, change := range changes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		existingUID, existingUpdatedAt, ok, err := r.findExisting(c, change.TaskID())
+		if err != nil {
+			return err
+		}
+		if ok && existingUpdatedAt.After(change.UpdatedAt()) {
+			continue // remote copy is newer; keep it
+		}
+
+		var msg *bytes.Buffer
+		if change.Tombstone != nil {
+			msg = tombstoneMessage(change.Tombstone)
+		} else {
+			msg = taskMessage(change.Task)
+		}
+		if err := c.Append(r.cfg.Mailbox, nil, time.Now(), msg); err != nil {
+			return fmt.Errorf("append task %q to mailbox %q: %w", change.TaskID(), r.cfg.Mailbox, err)
+		}
+		if ok {
+			staleUIDs = append(staleUIDs, existingUID)
+		}
+	}
+
+	if len(staleUIDs) > 0 {
+		if err := r.markDeleted(c, staleUIDs); err != nil {
+			return err
+		}
+		if err := c.Expunge(nil); err != nil {
+			return fmt.Errorf("expunge mailbox %q: %w", r.cfg.Mailbox, err)
+		}
+	}
+	return nil
+}
+
+// findExisting looks up the most recently updated message already in the
+// mailbox for taskID, if any. Multiple messages for the same task ID can
+// only happen if a prior Publish was interrupted before its Expunge;
+// last-writer-wins still applies, so this takes whichever is newest.
+func (r *IMAPTaskRepository) findExisting(c *client.Client, taskID model.TaskID) (uint32, time.Time, bool, error) {
+	criteria := imap.NewSearchCriteria()
+	criteria.Header.Add(headerTaskID, string(taskID))
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return 0, time.Time{}, false, fmt.Errorf("search for task %q: %w", taskID, err)
+	}
+	if len(uids) == 0 {
+		return 0, time.Time{}, false, nil
+	}
+
+	section := &imap.BodySectionName{}
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	messages := make(chan *imap.Message, len(uids))
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqset, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, messages)
+	}()
+
+	var bestUID uint32
+	var bestUpdatedAt time.Time
+	var found bool
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		header, err := readMessageHeader(body)
+		if err != nil {
+			continue
+		}
+		updatedAt, err := time.Parse(time.RFC3339, header.Get(headerUpdatedAt))
+		if err != nil {
+			continue
+		}
+		if !found || updatedAt.After(bestUpdatedAt) {
+			bestUID, bestUpdatedAt, found = msg.Uid, updatedAt, true
+		}
+	}
+	if err := <-done; err != nil {
+		return 0, time.Time{}, false, fmt.Errorf("fetch existing task %q: %w", taskID, err)
+	}
+	return bestUID, bestUpdatedAt, found, nil
+}
+
+func (r *IMAPTaskRepository) markDeleted(c *client.Client, uids []uint32) error {
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	item := imap.FormatFlagsOp(imap.AddFlags, true)
+	flags := []interface{}{imap.DeletedFlag}
+	if err := c.UidStore(seqset, item, flags, nil); err != nil {
+		return fmt.Errorf("mark uids %v deleted: %w", uids, err)
+	}
+	return nil
+}
+
+// taskMessage renders task as an RFC822 message for Append.
+func taskMessage(task model.Task) *bytes.Buffer {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Subject: %s\r\n", task.Title)
+	fmt.Fprintf(&b, "%s: %s\r\n", headerTaskID, task.ID)
+	fmt.Fprintf(&b, "%s: %s\r\n", headerUpdatedAt, task.UpdatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "%s: %s\r\n", headerStatus, task.Status)
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(&b, "%s: %s\r\n", headerTags, strings.Join(task.Tags, ","))
+	}
+	b.WriteString("\r\n")
+	// This Task model has no separate description field (unlike the
+	// DependsOn-style Task elsewhere in this module's history), so the body
+	// just mirrors the title for anyone skimming the mailbox directly.
+	b.WriteString(task.Title)
+	b.WriteString("\r\n")
+	return &b
+}
+
+// tombstoneMessage renders a deletion marker as an RFC822 message. Deletions
+// propagate as messages rather than as an immediate IMAP expunge, so a peer
+// that syncs later than the deletion still observes it via Fetch.
+func tombstoneMessage(tomb *TaskTombstone) *bytes.Buffer {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Subject: [deleted] %s\r\n", tomb.TaskID)
+	fmt.Fprintf(&b, "%s: %s\r\n", headerTaskID, tomb.TaskID)
+	fmt.Fprintf(&b, "%s: %s\r\n", headerUpdatedAt, tomb.DeletedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "%s: true\r\n", headerDeleted)
+	b.WriteString("\r\n")
+	return &b
+}
+
+// parseTaskMessage decodes one fetched message into either a task or (if its
+// headerDeleted header is set) a tombstone.
+func parseTaskMessage(remoteName string, uid uint32, body io.Reader) (model.Task, TaskTombstone, bool, error) {
+	header, err := readMessageHeader(body)
+	if err != nil {
+		return model.Task{}, TaskTombstone{}, false, fmt.Errorf("parse message uid %d: %w", uid, err)
+	}
+	id := model.TaskID(header.Get(headerTaskID))
+	if id == "" {
+		return model.Task{}, TaskTombstone{}, false, fmt.Errorf("message uid %d missing %s header", uid, headerTaskID)
+	}
+	updatedAt, err := time.Parse(time.RFC3339, header.Get(headerUpdatedAt))
+	if err != nil {
+		return model.Task{}, TaskTombstone{}, false, fmt.Errorf("message uid %d has invalid %s header: %w", uid, headerUpdatedAt, err)
+	}
+	if strings.EqualFold(header.Get(headerDeleted), "true") {
+		return model.Task{}, TaskTombstone{TaskID: id, DeletedAt: updatedAt}, true, nil
+	}
+
+	var tags []string
+	if raw := header.Get(headerTags); raw != "" {
+		tags = strings.Split(raw, ",")
+	}
+	task := model.Task{
+		ID:        id,
+		Title:     header.Get("Subject"),
+		Status:    model.TaskStatus(header.Get(headerStatus)),
+		Tags:      tags,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+		Origin:    model.RemoteTaskOrigin(remoteName),
+	}
+	return task, TaskTombstone{}, false, nil
+}
+
+// readMessageHeader parses an RFC822 message's header block. mail.ReadMessage
+// only requires a header section to be present, not a well-formed body, so
+// this also tolerates the header-only literal an IMAP server may return.
+func readMessageHeader(r io.Reader) (mail.Header, error) {
+	msg, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	return msg.Header, nil
+}
@@ -0,0 +1,250 @@
+// Package sync reconciles tasks against a pluggable remote backend, in the
+// spirit of gte's IMAP-backed task store: a RemoteTaskRepository supplies the
+// wire format and transport, and Syncer owns the reconciliation logic against
+// the local IndexStore.
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// TaskTombstone marks TaskID as deleted as of DeletedAt, so a sync round-trip
+// can remove a task locally or remotely without round-tripping its full body.
+type TaskTombstone struct {
+	TaskID    model.TaskID
+	DeletedAt time.Time
+}
+
+// TaskChange is one task-level delta published to a RemoteTaskRepository:
+// either an upsert (Tombstone nil) or a deletion (Tombstone set).
+type TaskChange struct {
+	Task      model.Task
+	Tombstone *TaskTombstone
+}
+
+// TaskID returns the ID of the task this change concerns, whether it's an
+// upsert or a deletion.
+func (c TaskChange) TaskID() model.TaskID {
+	if c.Tombstone != nil {
+		return c.Tombstone.TaskID
+	}
+	return c.Task.ID
+}
+
+// UpdatedAt returns the change's logical timestamp, for last-writer-wins
+// comparisons against an existing remote copy.
+func (c TaskChange) UpdatedAt() time.Time {
+	if c.Tombstone != nil {
+		return c.Tombstone.DeletedAt
+	}
+	return c.Task.UpdatedAt
+}
+
+// RemoteTaskRepository is a pluggable backend for syncing tasks with a remote
+// system. Syncer depends only on this interface; see IMAPTaskRepository for a
+// concrete implementation backed by an IMAP mailbox.
+type RemoteTaskRepository interface {
+	// Name identifies this repository, used to build the model.Task.Origin
+	// ("remote:<name>") of tasks it fetches that have no backing vault note.
+	Name() string
+	// Fetch returns every task created or updated, and every task deleted,
+	// on the remote side since the provided time. A zero since fetches the
+	// repository's entire task set.
+	Fetch(ctx context.Context, since time.Time) ([]model.Task, []TaskTombstone, error)
+	// Publish pushes local changes to the remote side.
+	Publish(ctx context.Context, changes []TaskChange) error
+}
+
+// Syncer reconciles a RemoteTaskRepository with a local IndexStore. Remote
+// tasks with no backing vault note are held in a single synthetic note (see
+// remoteNoteID) rather than requiring IndexStore itself to learn about
+// note-less tasks; its Content is a Markdown task list, so it can be
+// materialized to a real daily note file on disk on demand.
+type Syncer struct {
+	remote   RemoteTaskRepository
+	store    index.IndexStore
+	lastSync time.Time
+
+	// Resolver, if set, picks the winner between a locally stored copy of a
+	// remote task and the copy just fetched, overriding the default
+	// last-writer-wins comparison on UpdatedAt. It is not consulted for
+	// tombstones, which always win regardless of timestamp.
+	Resolver func(local, remote model.Task) model.Task
+}
+
+// NewSyncer wires a Syncer for the given remote/store pair. Both arguments
+// are required.
+func NewSyncer(remote RemoteTaskRepository, store index.IndexStore) (*Syncer, error) {
+	if remote == nil || store == nil {
+		return nil, fmt.Errorf("remote repository and store are required")
+	}
+	return &Syncer{remote: remote, store: store}, nil
+}
+
+// resolve picks the winner between local and remote using Resolver if set,
+// or last-writer-wins on UpdatedAt otherwise.
+func (s *Syncer) resolve(local, remote model.Task) model.Task {
+	if s.Resolver != nil {
+		return s.Resolver(local, remote)
+	}
+	if local.UpdatedAt.After(remote.UpdatedAt) {
+		return local
+	}
+	return remote
+}
+
+// remoteNoteID is the synthetic note ID under which a Syncer stores every
+// task fetched from its remote that isn't otherwise tied to a vault note.
+func (s *Syncer) remoteNoteID() model.NoteID {
+	return model.NoteID("remote/" + s.remote.Name() + ".md")
+}
+
+// Sync performs one full round-trip: Pull followed by Push.
+func (s *Syncer) Sync(ctx context.Context) error {
+	if err := s.Pull(ctx); err != nil {
+		return err
+	}
+	return s.Push(ctx)
+}
+
+// Pull fetches remote changes since the last successful Pull or Sync (or
+// the dawn of time on the first call) and reconciles them into the local
+// index, resolving conflicts via resolve.
+func (s *Syncer) Pull(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	remoteTasks, tombstones, err := s.remote.Fetch(ctx, s.lastSync)
+	if err != nil {
+		return fmt.Errorf("fetch from remote %q: %w", s.remote.Name(), err)
+	}
+	if err := s.reconcileRemote(ctx, remoteTasks, tombstones); err != nil {
+		return err
+	}
+
+	s.lastSync = time.Now().UTC()
+	return nil
+}
+
+// Push publishes every locally known vault-origin task to the remote.
+func (s *Syncer) Push(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	changes, err := s.localChanges(ctx)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := s.remote.Publish(ctx, changes); err != nil {
+		return fmt.Errorf("publish to remote %q: %w", s.remote.Name(), err)
+	}
+	return nil
+}
+
+// reconcileRemote merges fetched remote tasks into the synthetic remote
+// note, applying last-writer-wins: a fetched task only overwrites an
+// existing one (local or previously synced) when its UpdatedAt is at least
+// as new. Tombstones remove a task outright regardless of UpdatedAt.
+func (s *Syncer) reconcileRemote(ctx context.Context, remoteTasks []model.Task, tombstones []TaskTombstone) error {
+	existing, err := s.currentRemoteTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	noteID := s.remoteNoteID()
+
+	for _, remote := range remoteTasks {
+		remote.Origin = model.RemoteTaskOrigin(s.remote.Name())
+		remote.NoteID = noteID
+		if local, ok := existing[remote.ID]; ok {
+			existing[remote.ID] = s.resolve(local, remote)
+			continue
+		}
+		existing[remote.ID] = remote
+	}
+	for _, tomb := range tombstones {
+		delete(existing, tomb.TaskID)
+	}
+
+	tasks := make([]model.Task, 0, len(existing))
+	for _, t := range existing {
+		t.NoteID = noteID
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	note := &model.Note{
+		ID:      noteID,
+		Path:    string(noteID),
+		Title:   fmt.Sprintf("Remote tasks (%s)", s.remote.Name()),
+		Date:    time.Now().UTC(),
+		Content: remoteNoteContent(tasks),
+	}
+	return s.store.UpsertParsedNote(ctx, &parser.ParsedNote{
+		Note:       note,
+		Tasks:      tasks,
+		LogEntries: []model.LogEntry{},
+		Mentions:   []model.TaskMention{},
+		Links:      []model.NoteLink{},
+	})
+}
+
+// currentRemoteTasks returns the tasks currently stored under this Syncer's
+// synthetic remote note, keyed by ID.
+func (s *Syncer) currentRemoteTasks(ctx context.Context) (map[model.TaskID]model.Task, error) {
+	tasks, err := s.store.ListTasks(ctx, index.TaskFilter{NoteIDs: []model.NoteID{s.remoteNoteID()}})
+	if err != nil {
+		return nil, fmt.Errorf("list existing remote tasks: %w", err)
+	}
+	existing := make(map[model.TaskID]model.Task, len(tasks))
+	for _, t := range tasks {
+		existing[t.ID] = t
+	}
+	return existing, nil
+}
+
+// remoteNoteContent renders tasks as a Markdown checklist under a "Tasks"
+// section, matching the layout RegexNoteParser expects, so the synthetic
+// remote note can be materialized to a real daily note file unchanged.
+func remoteNoteContent(tasks []model.Task) string {
+	var b strings.Builder
+	b.WriteString("## Tasks\n")
+	for _, t := range tasks {
+		mark := " "
+		if t.Status == model.TaskStatusDone {
+			mark = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", mark, t.Title)
+	}
+	return b.String()
+}
+
+// localChanges collects every vault-origin task as a TaskChange to publish.
+// Tasks this Syncer itself stored from the remote (Origin != vault) are
+// never published back, so a task isn't reflected to its own source.
+func (s *Syncer) localChanges(ctx context.Context) ([]TaskChange, error) {
+	tasks, err := s.store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list local tasks: %w", err)
+	}
+	var changes []TaskChange
+	for _, t := range tasks {
+		if t.Origin != "" && t.Origin != model.TaskOriginVault {
+			continue
+		}
+		changes = append(changes, TaskChange{Task: t})
+	}
+	return changes, nil
+}
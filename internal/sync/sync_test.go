@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+type fakeRemote struct {
+	name       string
+	tasks      []model.Task
+	tombstones []TaskTombstone
+	published  []TaskChange
+}
+
+func (f *fakeRemote) Name() string { return f.name }
+
+func (f *fakeRemote) Fetch(ctx context.Context, since time.Time) ([]model.Task, []TaskTombstone, error) {
+	var tasks []model.Task
+	for _, t := range f.tasks {
+		if !since.IsZero() && t.UpdatedAt.Before(since) {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, f.tombstones, nil
+}
+
+func (f *fakeRemote) Publish(ctx context.Context, changes []TaskChange) error {
+	f.published = append(f.published, changes...)
+	return nil
+}
+
+func TestSyncerPullsRemoteTasksIntoSyntheticNote(t *testing.T) {
+	store := index.NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	remote := &fakeRemote{
+		name: "work-imap",
+		tasks: []model.Task{
+			{ID: "remote-1", Title: "Ship the report", Status: model.TaskStatusTodo, UpdatedAt: time.Now().UTC()},
+		},
+	}
+
+	syncer, err := NewSyncer(remote, store)
+	if err != nil {
+		t.Fatalf("NewSyncer() error = %v", err)
+	}
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("ListTasks() = %+v, want exactly one synced task", tasks)
+	}
+	if tasks[0].ID != "remote-1" || tasks[0].Origin != model.RemoteTaskOrigin("work-imap") {
+		t.Fatalf("tasks[0] = %+v, want ID remote-1 with origin remote:work-imap", tasks[0])
+	}
+}
+
+func TestSyncerLastWriterWinsKeepsNewerLocalTask(t *testing.T) {
+	store := index.NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	remote := &fakeRemote{name: "work-imap"}
+	syncer, err := NewSyncer(remote, store)
+	if err != nil {
+		t.Fatalf("NewSyncer() error = %v", err)
+	}
+
+	// Seed a newer local copy of the same task ID under the synthetic remote note.
+	newer := model.Task{ID: "remote-1", NoteID: syncer.remoteNoteID(), Title: "Newer title", Status: model.TaskStatusInProgress, UpdatedAt: time.Now().UTC()}
+	note := &model.Note{ID: syncer.remoteNoteID(), Path: string(syncer.remoteNoteID()), Title: "Remote tasks", Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: note, Tasks: []model.Task{newer}}); err != nil {
+		t.Fatalf("seed UpsertParsedNote() error = %v", err)
+	}
+
+	remote.tasks = []model.Task{
+		{ID: "remote-1", Title: "Stale title", Status: model.TaskStatusTodo, UpdatedAt: newer.UpdatedAt.Add(-time.Hour)},
+	}
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Newer title" {
+		t.Fatalf("ListTasks() = %+v, want the newer local title preserved", tasks)
+	}
+}
+
+func TestSyncerResolverOverridesLastWriterWins(t *testing.T) {
+	store := index.NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	remote := &fakeRemote{name: "work-imap"}
+	syncer, err := NewSyncer(remote, store)
+	if err != nil {
+		t.Fatalf("NewSyncer() error = %v", err)
+	}
+	// A Resolver that always keeps the remote copy, even when it's older.
+	syncer.Resolver = func(local, remote model.Task) model.Task { return remote }
+
+	newer := model.Task{ID: "remote-1", Title: "Newer local title", Status: model.TaskStatusInProgress, UpdatedAt: time.Now().UTC()}
+	note := &model.Note{ID: syncer.remoteNoteID(), Path: string(syncer.remoteNoteID()), Title: "Remote tasks", Date: time.Now().UTC()}
+	if err := store.UpsertParsedNote(ctx, &parser.ParsedNote{Note: note, Tasks: []model.Task{newer}}); err != nil {
+		t.Fatalf("seed UpsertParsedNote() error = %v", err)
+	}
+
+	remote.tasks = []model.Task{
+		{ID: "remote-1", Title: "Stale remote title", Status: model.TaskStatusTodo, UpdatedAt: newer.UpdatedAt.Add(-time.Hour)},
+	}
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Stale remote title" {
+		t.Fatalf("ListTasks() = %+v, want the Resolver's chosen remote title", tasks)
+	}
+}
+
+func TestSyncerTombstoneRemovesTask(t *testing.T) {
+	store := index.NewInMemoryIndexStore()
+	ctx := context.Background()
+
+	remote := &fakeRemote{
+		name: "work-imap",
+		tasks: []model.Task{
+			{ID: "remote-1", Title: "Ship the report", Status: model.TaskStatusTodo, UpdatedAt: time.Now().UTC()},
+		},
+	}
+	syncer, err := NewSyncer(remote, store)
+	if err != nil {
+		t.Fatalf("NewSyncer() error = %v", err)
+	}
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("first Sync() error = %v", err)
+	}
+
+	remote.tasks = nil
+	remote.tombstones = []TaskTombstone{{TaskID: "remote-1", DeletedAt: time.Now().UTC()}}
+	if err := syncer.Sync(ctx); err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("ListTasks() = %+v, want the tombstoned task removed", tasks)
+	}
+}
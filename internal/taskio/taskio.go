@@ -0,0 +1,283 @@
+// Package taskio translates between model.Task/model.LogEntry and the JSON
+// shape Taskwarrior's "task export"/"task import" commands produce, so a
+// vault's tasks can travel to and from the Taskwarrior ecosystem without
+// making Markdown anything other than the source of truth.
+package taskio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+// timeLayout is the timestamp format Taskwarrior's JSON export uses for
+// entry/modified/end: "20060102T150405Z".
+const timeLayout = "20060102T150405Z"
+
+// importOrigin is this package's RemoteTaskRepository-style name: imported
+// tasks get Origin model.RemoteTaskOrigin(importOrigin), the same convention
+// internal/sync uses for tasks with no backing vault note.
+const importOrigin = "taskwarrior-import"
+
+// importNoteID is the synthetic note every Import call upserts its tasks and
+// annotations under, mirroring internal/sync's remoteNoteID pattern. Calling
+// Import again with the same export fully replaces this note's indexed
+// state, which is what makes Import idempotent keyed on uuid<->TaskID.
+const importNoteID model.NoteID = "import/taskwarrior.md"
+
+// statusOut maps model.TaskStatus to Taskwarrior's status vocabulary.
+// Taskwarrior has no "in progress" status distinct from "pending"; that
+// distinction is lost on export, and reimporting a "pending" task always
+// lands back on TaskStatusTodo rather than TaskStatusInProgress.
+var statusOut = map[model.TaskStatus]string{
+	model.TaskStatusTodo:       "pending",
+	model.TaskStatusInProgress: "pending",
+	model.TaskStatusBlocked:    "waiting",
+	model.TaskStatusDone:       "completed",
+}
+
+// statusIn maps a Taskwarrior status back to model.TaskStatus. "deleted"
+// tasks aren't dropped (Import has no deletion semantics of its own) and are
+// treated as done, the closest available terminal state.
+var statusIn = map[string]model.TaskStatus{
+	"pending":   model.TaskStatusTodo,
+	"waiting":   model.TaskStatusBlocked,
+	"completed": model.TaskStatusDone,
+	"deleted":   model.TaskStatusDone,
+}
+
+// priorityTagPrefix and projectTagPrefix are the hierarchical tag prefixes
+// (see index.expandTag) this package reads "priority" and "project" from.
+// model.Task has no dedicated fields for either, so a "priority:h" or
+// "project:yapper" tag stands in, matching the repo's existing
+// colon-hierarchy tag convention rather than inventing a new one.
+const (
+	priorityTagPrefix = "priority:"
+	projectTagPrefix  = "project:"
+)
+
+// annotation is one entry of Taskwarrior's annotations array.
+type annotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// taskwarriorTask is the JSON shape of a single task in a Taskwarrior
+// export/import document.
+type taskwarriorTask struct {
+	UUID        string       `json:"uuid"`
+	Description string       `json:"description"`
+	Status      string       `json:"status"`
+	Entry       string       `json:"entry"`
+	Modified    string       `json:"modified,omitempty"`
+	End         string       `json:"end,omitempty"`
+	Project     string       `json:"project,omitempty"`
+	Priority    string       `json:"priority,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Annotations []annotation `json:"annotations,omitempty"`
+}
+
+// Export writes every task currently in store to w as a Taskwarrior-shaped
+// JSON array, with each task's related LogEntry rows (via
+// GetLogEntriesForTask) rendered as its annotations.
+func Export(ctx context.Context, store index.IndexStore, w io.Writer) error {
+	tasks, err := store.ListTasks(ctx, index.TaskFilter{})
+	if err != nil {
+		return fmt.Errorf("taskio: list tasks: %w", err)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	out := make([]taskwarriorTask, 0, len(tasks))
+	for _, t := range tasks {
+		entries, err := store.GetLogEntriesForTask(ctx, t.ID)
+		if err != nil {
+			return fmt.Errorf("taskio: log entries for task %q: %w", t.ID, err)
+		}
+		out = append(out, toTaskwarrior(t, entries))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("taskio: encode export: %w", err)
+	}
+	return nil
+}
+
+func toTaskwarrior(t model.Task, entries []model.LogEntry) taskwarriorTask {
+	tw := taskwarriorTask{
+		UUID:        string(t.ID),
+		Description: t.Title,
+		Status:      statusOut[t.Status],
+		Entry:       t.CreatedAt.UTC().Format(timeLayout),
+		Modified:    t.UpdatedAt.UTC().Format(timeLayout),
+		Tags:        t.Tags,
+	}
+	if tw.Status == "" {
+		tw.Status = "pending"
+	}
+	if t.CompletedAt != nil {
+		tw.End = t.CompletedAt.UTC().Format(timeLayout)
+	}
+	for _, tag := range t.Tags {
+		switch {
+		case strings.HasPrefix(tag, projectTagPrefix):
+			tw.Project = strings.TrimPrefix(tag, projectTagPrefix)
+		case strings.HasPrefix(tag, priorityTagPrefix):
+			tw.Priority = strings.ToUpper(strings.TrimPrefix(tag, priorityTagPrefix))
+		}
+	}
+	for _, e := range entries {
+		tw.Annotations = append(tw.Annotations, annotation{
+			Entry:       e.Timestamp.UTC().Format(timeLayout),
+			Description: e.Content,
+		})
+	}
+	return tw
+}
+
+// Import decodes a Taskwarrior-shaped JSON array from r and upserts its
+// tasks (and their annotations, as LogEntry rows) into store under a single
+// synthetic note, keyed on uuid<->TaskID. Calling Import again with the same
+// or an updated export replaces that note's entire indexed state, so
+// importing is idempotent: re-running it with unchanged input is a no-op in
+// effect, and re-running it with an updated export never leaves stale tasks
+// behind.
+func Import(ctx context.Context, store index.IndexStore, r io.Reader) error {
+	var in []taskwarriorTask
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return fmt.Errorf("taskio: decode import: %w", err)
+	}
+
+	tasks := make([]model.Task, 0, len(in))
+	var logEntries []model.LogEntry
+	for _, tw := range in {
+		task, err := fromTaskwarrior(tw)
+		if err != nil {
+			return fmt.Errorf("taskio: task %q: %w", tw.UUID, err)
+		}
+		tasks = append(tasks, task)
+		for i, ann := range tw.Annotations {
+			entry, err := fromAnnotation(task.ID, i, ann)
+			if err != nil {
+				return fmt.Errorf("taskio: task %q annotation %d: %w", tw.UUID, i, err)
+			}
+			logEntries = append(logEntries, entry)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	note := &model.Note{
+		ID:      importNoteID,
+		Path:    string(importNoteID),
+		Title:   "Taskwarrior import",
+		Date:    time.Now().UTC(),
+		Content: importNoteContent(tasks),
+	}
+	err := store.UpsertParsedNote(ctx, &parser.ParsedNote{
+		Note:       note,
+		Tasks:      tasks,
+		LogEntries: logEntries,
+		Mentions:   []model.TaskMention{},
+		Links:      []model.NoteLink{},
+	})
+	if err != nil {
+		return fmt.Errorf("taskio: upsert import note: %w", err)
+	}
+	return nil
+}
+
+func fromTaskwarrior(tw taskwarriorTask) (model.Task, error) {
+	if tw.UUID == "" {
+		return model.Task{}, fmt.Errorf("missing uuid")
+	}
+	entry, err := parseTime(tw.Entry)
+	if err != nil {
+		return model.Task{}, fmt.Errorf("entry: %w", err)
+	}
+	modified := entry
+	if tw.Modified != "" {
+		modified, err = parseTime(tw.Modified)
+		if err != nil {
+			return model.Task{}, fmt.Errorf("modified: %w", err)
+		}
+	}
+
+	status, ok := statusIn[tw.Status]
+	if !ok {
+		status = model.TaskStatusTodo
+	}
+
+	tags := append([]string(nil), tw.Tags...)
+	if tw.Project != "" {
+		tags = append(tags, projectTagPrefix+tw.Project)
+	}
+	if tw.Priority != "" {
+		tags = append(tags, priorityTagPrefix+strings.ToLower(tw.Priority))
+	}
+
+	task := model.Task{
+		ID:        model.TaskID(tw.UUID),
+		NoteID:    importNoteID,
+		Title:     tw.Description,
+		Status:    status,
+		Tags:      tags,
+		CreatedAt: entry,
+		UpdatedAt: modified,
+		Origin:    model.RemoteTaskOrigin(importOrigin),
+	}
+	if tw.End != "" {
+		end, err := parseTime(tw.End)
+		if err != nil {
+			return model.Task{}, fmt.Errorf("end: %w", err)
+		}
+		task.CompletedAt = &end
+	}
+	return task, nil
+}
+
+func fromAnnotation(taskID model.TaskID, seq int, ann annotation) (model.LogEntry, error) {
+	ts, err := parseTime(ann.Entry)
+	if err != nil {
+		return model.LogEntry{}, fmt.Errorf("entry: %w", err)
+	}
+	return model.LogEntry{
+		ID:        model.LogEntryID(fmt.Sprintf("%s-annotation-%d", taskID, seq)),
+		NoteID:    importNoteID,
+		Timestamp: ts,
+		Content:   ann.Description,
+		TaskRefs:  []model.TaskID{taskID},
+	}, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse timestamp %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// importNoteContent renders tasks as a Markdown checklist, matching the
+// layout internal/sync's remoteNoteContent uses for the same purpose, so the
+// synthetic import note can be materialized to a real vault file unchanged.
+func importNoteContent(tasks []model.Task) string {
+	var b strings.Builder
+	b.WriteString("## Tasks\n")
+	for _, t := range tasks {
+		mark := " "
+		if t.Status == model.TaskStatusDone {
+			mark = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] %s\n", mark, t.Title)
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package taskio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/weakphish/yapper/internal/index"
+	"github.com/weakphish/yapper/internal/model"
+	"github.com/weakphish/yapper/internal/parser"
+)
+
+func seedStore(t *testing.T, store index.IndexStore, tasks []model.Task, logs []model.LogEntry) {
+	t.Helper()
+	note := &model.Note{ID: "note.md", Path: "note.md", Title: "Note", Date: time.Now().UTC()}
+	err := store.UpsertParsedNote(context.Background(), &parser.ParsedNote{
+		Note:       note,
+		Tasks:      tasks,
+		LogEntries: logs,
+		Mentions:   []model.TaskMention{},
+		Links:      []model.NoteLink{},
+	})
+	if err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+}
+
+func TestExportEmitsTaskwarriorShape(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	completed := created.Add(24 * time.Hour)
+	store := index.NewInMemoryIndexStore()
+	seedStore(t, store, []model.Task{
+		{
+			ID:          "T-1",
+			NoteID:      "note.md",
+			Title:       "Ship it",
+			Status:      model.TaskStatusDone,
+			Tags:        []string{"urgent", "project:yapper", "priority:h"},
+			CreatedAt:   created,
+			UpdatedAt:   completed,
+			CompletedAt: &completed,
+		},
+	}, []model.LogEntry{
+		{ID: "l1", NoteID: "note.md", Timestamp: created, Content: "kicked off", TaskRefs: []model.TaskID{"T-1"}},
+	})
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), store, &buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	var out []taskwarriorTask
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal export: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("expected 1 exported task, got %d", len(out))
+	}
+	tw := out[0]
+	if tw.UUID != "T-1" || tw.Description != "Ship it" || tw.Status != "completed" {
+		t.Fatalf("unexpected task shape: %+v", tw)
+	}
+	if tw.Project != "yapper" || tw.Priority != "H" {
+		t.Fatalf("expected project/priority derived from tags, got %+v", tw)
+	}
+	if tw.End != completed.Format(timeLayout) {
+		t.Fatalf("End = %q, want %q", tw.End, completed.Format(timeLayout))
+	}
+	if len(tw.Annotations) != 1 || tw.Annotations[0].Description != "kicked off" {
+		t.Fatalf("expected 1 annotation from the log entry, got %+v", tw.Annotations)
+	}
+}
+
+func TestImportIsIdempotentKeyedOnUUID(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	exportJSON := []byte(`[{
+		"uuid": "abc-123",
+		"description": "Renew passport",
+		"status": "pending",
+		"entry": "` + created.Format(timeLayout) + `",
+		"tags": ["errand"],
+		"annotations": [{"entry": "` + created.Format(timeLayout) + `", "description": "started the form"}]
+	}]`)
+
+	store := index.NewInMemoryIndexStore()
+	for i := 0; i < 2; i++ {
+		if err := Import(context.Background(), store, bytes.NewReader(exportJSON)); err != nil {
+			t.Fatalf("Import() call %d: %v", i, err)
+		}
+	}
+
+	tasks, err := store.ListTasks(context.Background(), index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected Import to be idempotent and produce exactly 1 task, got %d: %+v", len(tasks), tasks)
+	}
+	task := tasks[0]
+	if task.ID != "abc-123" || task.Title != "Renew passport" || task.Status != model.TaskStatusTodo {
+		t.Fatalf("unexpected imported task: %+v", task)
+	}
+
+	entries, err := store.GetLogEntriesForTask(context.Background(), task.ID)
+	if err != nil {
+		t.Fatalf("GetLogEntriesForTask: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Content != "started the form" {
+		t.Fatalf("expected the annotation to round-trip as a log entry, got %+v", entries)
+	}
+}
+
+func TestExportImportRoundTripPreservesSupportedFields(t *testing.T) {
+	created := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	completed := created.Add(48 * time.Hour)
+	src := index.NewInMemoryIndexStore()
+	seedStore(t, src, []model.Task{
+		{
+			ID:          "T-42",
+			NoteID:      "note.md",
+			Title:       "Pay rent",
+			Status:      model.TaskStatusDone,
+			Tags:        []string{"home"},
+			CreatedAt:   created,
+			UpdatedAt:   completed,
+			CompletedAt: &completed,
+		},
+	}, nil)
+
+	var buf bytes.Buffer
+	if err := Export(context.Background(), src, &buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	dst := index.NewInMemoryIndexStore()
+	if err := Import(context.Background(), dst, &buf); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	tasks, err := dst.ListTasks(context.Background(), index.TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 round-tripped task, got %d", len(tasks))
+	}
+	got := tasks[0]
+	if got.ID != "T-42" || got.Title != "Pay rent" || got.Status != model.TaskStatusDone {
+		t.Fatalf("round-tripped task lost supported fields: %+v", got)
+	}
+	if got.CompletedAt == nil || !got.CompletedAt.Equal(completed) {
+		t.Fatalf("CompletedAt = %v, want %v", got.CompletedAt, completed)
+	}
+}
@@ -1,4 +1,4 @@
-package model
+package taskmodel
 
 type Note struct {
 	ID           int
@@ -0,0 +1,219 @@
+package taskmodel
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"time"
+
+	"github.com/weakphish/yapper/internal/files"
+	"github.com/weakphish/yapper/internal/recurrence"
+)
+
+type TaskStatus int
+
+const (
+	Todo TaskStatus = iota
+	InProgress
+	Completed
+)
+
+type Task struct {
+	ID          string
+	Title       string
+	Description string
+	Status      TaskStatus
+	CreatedAt   time.Time
+	StartedAt   *time.Time // pointer to allow nullability in gorm
+	CompletedAt *time.Time
+	// DependsOn holds every task that must be Completed before this one can
+	// start. It's a many-to-many self-relation (a task can block, and be
+	// blocked by, more than one other task), backed by a join table the same
+	// way Note.RelatedTasks joins notes to tasks.
+	DependsOn []*Task `gorm:"many2many:task_dependencies;"`
+	// Dependents is the reverse of DependsOn: every task that depends on this
+	// one. It's derived (populate it via the graph package) rather than
+	// gorm-managed, since a self-referential many2many can only own one
+	// direction of the join table.
+	Dependents []*Task `gorm:"-"`
+	// Attachments lists the files uploaded to this task. Their binary content
+	// lives in AttachmentStore, not in this slice or the database row.
+	Attachments []*files.File `gorm:"foreignKey:TaskID"`
+	// Recurrence holds an RFC 5545 RRULE string (e.g.
+	// "FREQ=WEEKLY;BYDAY=MO,WE,FR"), or "" for a one-off task. Complete uses
+	// it to spawn the next occurrence.
+	Recurrence string
+	// TimeEntries records the spans of time this task was actively worked
+	// on, via StartTimer/StopTimer or the pomodoro package.
+	TimeEntries []*TimeEntry `gorm:"foreignKey:TaskID"`
+}
+
+// TimeEntry is a single span of time spent on a task, from StartTimer until
+// a matching StopTimer. EndedAt is nil while the entry is still open.
+type TimeEntry struct {
+	ID        string
+	TaskID    string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Note      string
+}
+
+// StartTimer opens a new TimeEntry on t. It does not close any entry left
+// open by a prior StartTimer; callers that care about a single active timer
+// per task should StopTimer first.
+func (t *Task) StartTimer() (*TimeEntry, error) {
+	id, err := newRandomID()
+	if err != nil {
+		return nil, err
+	}
+	entry := &TimeEntry{
+		ID:        id,
+		TaskID:    t.ID,
+		StartedAt: time.Now(),
+	}
+	t.TimeEntries = append(t.TimeEntries, entry)
+	return entry, nil
+}
+
+// StopTimer closes the most recently opened TimeEntry that doesn't yet have
+// an EndedAt, recording note on it. It is a no-op if there is no open entry.
+func (t *Task) StopTimer(note string) *TimeEntry {
+	for i := len(t.TimeEntries) - 1; i >= 0; i-- {
+		entry := t.TimeEntries[i]
+		if entry.EndedAt == nil {
+			now := time.Now()
+			entry.EndedAt = &now
+			entry.Note = note
+			return entry
+		}
+	}
+	return nil
+}
+
+// TotalDuration sums every closed TimeEntry's span. Entries still open
+// (EndedAt is nil) are not counted.
+func (t *Task) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		if entry.EndedAt != nil {
+			total += entry.EndedAt.Sub(entry.StartedAt)
+		}
+	}
+	return total
+}
+
+func NewTask(id, title, description string) *Task {
+	return &Task{
+		ID:          id,
+		Title:       title,
+		Description: description,
+		Status:      Todo,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// AttachmentStore is the files.Store that AttachFile, OpenAttachment, and
+// RemoveAttachment delegate to. A caller wanting attachment support assigns
+// it once at startup, the same way this package otherwise expects callers to
+// supply their own *gorm.DB rather than owning one itself.
+var AttachmentStore *files.Store
+
+// AttachFile stores r's contents as a new attachment on the task and appends
+// it to t.Attachments.
+func (t *Task) AttachFile(r io.Reader, name string) (*files.File, error) {
+	if AttachmentStore == nil {
+		return nil, fmt.Errorf("model: no AttachmentStore configured")
+	}
+	f, err := AttachmentStore.Save(r, t.ID, name, mimeForName(name), "")
+	if err != nil {
+		return nil, err
+	}
+	t.Attachments = append(t.Attachments, f)
+	return f, nil
+}
+
+// OpenAttachment opens the attachment with the given ID for reading.
+func (t *Task) OpenAttachment(id string) (io.ReadCloser, error) {
+	if AttachmentStore == nil {
+		return nil, fmt.Errorf("model: no AttachmentStore configured")
+	}
+	return AttachmentStore.Open(id)
+}
+
+// RemoveAttachment deletes the attachment with the given ID from the store
+// and from t.Attachments.
+func (t *Task) RemoveAttachment(id string) error {
+	if AttachmentStore == nil {
+		return fmt.Errorf("model: no AttachmentStore configured")
+	}
+	if err := AttachmentStore.Remove(id); err != nil {
+		return err
+	}
+	filtered := t.Attachments[:0]
+	for _, f := range t.Attachments {
+		if f.ID != id {
+			filtered = append(filtered, f)
+		}
+	}
+	t.Attachments = filtered
+	return nil
+}
+
+// mimeForName guesses a MIME type from name's extension, falling back to a
+// generic binary type when the extension is unknown or absent.
+func mimeForName(name string) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// Complete marks t Completed. If t is recurring (Recurrence is set and
+// parses), it also spawns the next occurrence: a fresh Task with the same
+// Title/Description/Recurrence, CreatedAt set to that occurrence's date, and
+// Status/StartedAt/CompletedAt all reset to their zero value. The caller is
+// responsible for persisting both t and the returned task; Complete returns
+// nil for a non-recurring task or one whose rule has no further occurrences.
+func (t *Task) Complete() *Task {
+	now := time.Now()
+	t.Status = Completed
+	t.CompletedAt = &now
+
+	if t.Recurrence == "" {
+		return nil
+	}
+	rule, err := recurrence.Parse(t.Recurrence)
+	if err != nil {
+		return nil
+	}
+	next, ok := rule.NextOccurrence(now)
+	if !ok {
+		return nil
+	}
+	id, err := newRandomID()
+	if err != nil {
+		return nil
+	}
+
+	return &Task{
+		ID:          id,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      Todo,
+		CreatedAt:   next,
+		Recurrence:  t.Recurrence,
+	}
+}
+
+// newRandomID generates a random 128-bit hex task ID, the same scheme
+// files.Store uses for attachment IDs.
+func newRandomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
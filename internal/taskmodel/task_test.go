@@ -0,0 +1,78 @@
+package taskmodel
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/weakphish/yapper/internal/files"
+)
+
+// withAttachmentStore points the package-level AttachmentStore at a fresh
+// in-memory store for the duration of a test and restores whatever was
+// there before, so tests can run in any order without leaking state.
+func withAttachmentStore(t *testing.T) {
+	t.Helper()
+	prev := AttachmentStore
+	AttachmentStore = files.NewStore(afero.NewMemMapFs(), "attachments", files.DefaultMaxSize)
+	t.Cleanup(func() { AttachmentStore = prev })
+}
+
+func TestTaskAttachFileRoundTrips(t *testing.T) {
+	withAttachmentStore(t)
+
+	task := NewTask("task-1", "Write report", "")
+	f, err := task.AttachFile(strings.NewReader("hello"), "notes.txt")
+	if err != nil {
+		t.Fatalf("AttachFile: %v", err)
+	}
+	if len(task.Attachments) != 1 || task.Attachments[0].ID != f.ID {
+		t.Fatalf("AttachFile did not append to Attachments: %+v", task.Attachments)
+	}
+
+	rc, err := task.OpenAttachment(f.ID)
+	if err != nil {
+		t.Fatalf("OpenAttachment: %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read attachment: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("attachment content = %q, want %q", data, "hello")
+	}
+}
+
+func TestTaskRemoveAttachment(t *testing.T) {
+	withAttachmentStore(t)
+
+	task := NewTask("task-1", "Write report", "")
+	f, err := task.AttachFile(strings.NewReader("hello"), "notes.txt")
+	if err != nil {
+		t.Fatalf("AttachFile: %v", err)
+	}
+
+	if err := task.RemoveAttachment(f.ID); err != nil {
+		t.Fatalf("RemoveAttachment: %v", err)
+	}
+	if len(task.Attachments) != 0 {
+		t.Fatalf("RemoveAttachment left %d attachments, want 0", len(task.Attachments))
+	}
+	if _, err := task.OpenAttachment(f.ID); err == nil {
+		t.Fatal("OpenAttachment succeeded after RemoveAttachment, want error")
+	}
+}
+
+func TestTaskAttachFileNoStoreConfigured(t *testing.T) {
+	prev := AttachmentStore
+	AttachmentStore = nil
+	t.Cleanup(func() { AttachmentStore = prev })
+
+	task := NewTask("task-1", "Write report", "")
+	if _, err := task.AttachFile(strings.NewReader("hello"), "notes.txt"); err == nil {
+		t.Fatal("AttachFile succeeded with no AttachmentStore configured, want error")
+	}
+}
@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatter holds a note's decoded YAML frontmatter block, along with the
+// conventional fields LoadNote promotes onto the Note itself. Raw keys are
+// normalized to lowercase, the way zk does, so later equality lookups (e.g.
+// NoteFilter.Metadata) don't have to guess at casing.
+type frontmatter struct {
+	Raw     map[string]any
+	Aliases []string
+	Title   string
+	Date    *time.Time
+}
+
+// parseFrontmatter reads a note's leading YAML frontmatter block (delimited
+// by "---" lines), if present. It returns ok=false if the note has no
+// frontmatter or it doesn't parse as YAML.
+func parseFrontmatter(content string) (frontmatter, bool) {
+	fm, ok := splitFrontmatter(content)
+	if !ok {
+		return frontmatter{}, false
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(fm), &doc); err != nil {
+		return frontmatter{}, false
+	}
+
+	raw := make(map[string]any, len(doc))
+	for k, v := range doc {
+		raw[strings.ToLower(k)] = v
+	}
+
+	result := frontmatter{Raw: raw}
+	for _, key := range []string{"aliases", "alias"} {
+		result.Aliases = append(result.Aliases, stringsFromYAMLValue(raw[key])...)
+	}
+	if title, ok := raw["title"].(string); ok && strings.TrimSpace(title) != "" {
+		result.Title = strings.TrimSpace(title)
+	}
+	switch v := raw["date"].(type) {
+	case string:
+		if parsed, ok := parseFrontmatterDate(v); ok {
+			result.Date = &parsed
+		}
+	case time.Time:
+		// yaml.v3 auto-decodes an unquoted ISO date scalar (date: 2024-03-05)
+		// as a time.Time rather than a string, so it never reaches
+		// parseFrontmatterDate's string layouts.
+		result.Date = &v
+	}
+	return result, true
+}
+
+// frontmatterDateLayouts lists the date formats a "date" frontmatter key may
+// use, tried in order.
+var frontmatterDateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+func parseFrontmatterDate(s string) (time.Time, bool) {
+	for _, layout := range frontmatterDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitFrontmatter returns the YAML body between a note's leading "---"
+// delimiters, if present.
+func splitFrontmatter(content string) (string, bool) {
+	const delim = "---"
+	trimmed := strings.TrimLeft(content, "\ufeff \t\r\n")
+	if !strings.HasPrefix(trimmed, delim) {
+		return "", false
+	}
+	rest := trimmed[len(delim):]
+	idx := strings.Index(rest, "\n"+delim)
+	if idx < 0 {
+		return "", false
+	}
+	return rest[:idx], true
+}
+
+// stringsFromYAMLValue normalizes a decoded YAML value into a slice of
+// strings, accepting either a single scalar or a sequence of scalars.
+func stringsFromYAMLValue(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var out []string
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -18,6 +18,9 @@ import (
 // repository. Callers use this interface exclusively so the backing storage
 // (filesystem, remote service, etc.) remains abstracted away.
 type Vault interface {
+	// Name returns the label a multi-vault Registry should use to identify
+	// this vault (e.g. in a VaultName field on cross-vault query results).
+	Name() string
 	// Root returns the root directory of the vault.
 	Root() string
 	// ListNotePaths returns every Markdown file path within the vault. The
@@ -30,20 +33,40 @@ type Vault interface {
 	// helper for callers that need the entire vault materialized at once and is
 	// implemented in terms of ListNotePaths + LoadNote.
 	LoadNotes(ctx context.Context) ([]*model.Note, error)
+	// WalkNotes streams every note in the vault to fn, one at a time, without
+	// materializing the whole vault in memory. It stops and returns fn's error
+	// as soon as fn returns one.
+	WalkNotes(ctx context.Context, fn func(*model.Note) error) error
 }
 
 // FileSystemVault implements Vault by reading directly from the local
 // filesystem. It expects Markdown notes to live beneath a single root directory.
 type FileSystemVault struct {
+	name string
 	root string
 }
 
-// NewFileSystemVault constructs a FileSystemVault rooted at the provided path.
-// The root must exist and be a directory.
+// NewFileSystemVault constructs a FileSystemVault rooted at the provided path,
+// named after the root directory's base name. Use NewNamedFileSystemVault to
+// pick an explicit name, e.g. when registering several vaults by hand in a
+// Registry.
 func NewFileSystemVault(root string) (*FileSystemVault, error) {
 	if root == "" {
 		return nil, errors.New("vault root cannot be empty")
 	}
+	return NewNamedFileSystemVault(filepath.Base(filepath.Clean(root)), root)
+}
+
+// NewNamedFileSystemVault constructs a FileSystemVault rooted at the provided
+// path and labeled with the given name. The root must exist and be a
+// directory.
+func NewNamedFileSystemVault(name, root string) (*FileSystemVault, error) {
+	if name == "" {
+		return nil, errors.New("vault name cannot be empty")
+	}
+	if root == "" {
+		return nil, errors.New("vault root cannot be empty")
+	}
 
 	info, err := os.Stat(root)
 	if err != nil {
@@ -53,7 +76,12 @@ func NewFileSystemVault(root string) (*FileSystemVault, error) {
 		return nil, fmt.Errorf("vault root %q is not a directory", root)
 	}
 
-	return &FileSystemVault{root: filepath.Clean(root)}, nil
+	return &FileSystemVault{name: name, root: filepath.Clean(root)}, nil
+}
+
+// Name returns the label this vault was registered under.
+func (v *FileSystemVault) Name() string {
+	return v.name
 }
 
 // Root returns the normalized root directory path for the vault.
@@ -126,16 +154,30 @@ func (v *FileSystemVault) LoadNote(ctx context.Context, path string) (*model.Not
 
 	notePath := filepath.ToSlash(cleanPath)
 	title := deriveTitle(string(data), notePath)
-	modTime := info.ModTime().UTC()
+	date := info.ModTime().UTC()
 
 	note := &model.Note{
 		ID:      model.NoteID(notePath),
 		Path:    notePath,
 		Title:   title,
-		Date:    modTime,
+		Date:    date,
 		Content: string(data),
 	}
 
+	// Frontmatter overrides the Markdown-heading/filename title and the
+	// file's modification-time date when present; deriveTitle and the
+	// modtime above remain the fallback for notes without it.
+	if fm, ok := parseFrontmatter(string(data)); ok {
+		note.Frontmatter = fm.Raw
+		note.Aliases = fm.Aliases
+		if fm.Title != "" {
+			note.Title = fm.Title
+		}
+		if fm.Date != nil {
+			note.Date = *fm.Date
+		}
+	}
+
 	return note, nil
 }
 
@@ -143,23 +185,40 @@ func (v *FileSystemVault) LoadNote(ctx context.Context, path string) (*model.Not
 // simple tests and prototyping code that benefit from eagerly materializing the
 // vault.
 func (v *FileSystemVault) LoadNotes(ctx context.Context) ([]*model.Note, error) {
-	paths, err := v.ListNotePaths(ctx)
+	var notes []*model.Note
+	err := v.WalkNotes(ctx, func(note *model.Note) error {
+		notes = append(notes, note)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return notes, nil
+}
+
+// WalkNotes lists the vault's note paths and loads them one at a time,
+// invoking fn with each note as soon as it's read. Unlike LoadNotes, it never
+// holds more than one note's content in memory at once, so a full reindex of
+// a very large vault doesn't have to materialize the whole thing first.
+func (v *FileSystemVault) WalkNotes(ctx context.Context, fn func(*model.Note) error) error {
+	paths, err := v.ListNotePaths(ctx)
+	if err != nil {
+		return err
+	}
 
-	notes := make([]*model.Note, 0, len(paths))
 	for _, p := range paths {
 		if err := ensureContext(ctx); err != nil {
-			return nil, err
+			return err
 		}
 		note, err := v.LoadNote(ctx, p)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(note); err != nil {
+			return err
 		}
-		notes = append(notes, note)
 	}
-	return notes, nil
+	return nil
 }
 
 // normalizePath ensures that the provided path points within the vault root and
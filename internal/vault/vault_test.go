@@ -104,3 +104,46 @@ func TestFileSystemVault_LoadNotes(t *testing.T) {
 		t.Fatalf("unexpected paths: %v", gotPaths)
 	}
 }
+
+// TestFileSystemVault_LoadNoteFrontmatterOverrides verifies that frontmatter
+// title/date/aliases win over the Markdown-heading/filename/modtime
+// fallbacks, and that arbitrary custom keys surface on Note.Frontmatter.
+func TestFileSystemVault_LoadNoteFrontmatterOverrides(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	content := "---\n" +
+		"title: Custom Title\n" +
+		"date: 2024-03-05\n" +
+		"aliases: [Alt Name]\n" +
+		"priority: high\n" +
+		"---\n" +
+		"# Heading Ignored\n" +
+		"body"
+	path := filepath.Join(tmp, "note.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write note.md: %v", err)
+	}
+
+	v, err := NewFileSystemVault(tmp)
+	if err != nil {
+		t.Fatalf("NewFileSystemVault: %v", err)
+	}
+
+	note, err := v.LoadNote(ctx, "note.md")
+	if err != nil {
+		t.Fatalf("LoadNote: %v", err)
+	}
+	if note.Title != "Custom Title" {
+		t.Fatalf("Title = %q, want frontmatter override", note.Title)
+	}
+	if want := "2024-03-05"; note.Date.Format("2006-01-02") != want {
+		t.Fatalf("Date = %v, want %s", note.Date, want)
+	}
+	if len(note.Aliases) != 1 || note.Aliases[0] != "Alt Name" {
+		t.Fatalf("Aliases = %v, want [Alt Name]", note.Aliases)
+	}
+	if got := note.Frontmatter["priority"]; got != "high" {
+		t.Fatalf("Frontmatter[priority] = %v, want %q", got, "high")
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// consoleHandler renders records as a single colorized line of the form
+// "15:04:05 LVL  msg key=val ...", meant for a human watching stderr
+// rather than for machine parsing (that's what the JSON file sink is for).
+type consoleHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  slog.Level
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newConsoleHandler(w io.Writer, level slog.Level) *consoleHandler {
+	return &consoleHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *consoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+	buf.WriteString(record.Time.Format(time.TimeOnly))
+	buf.WriteByte(' ')
+	buf.WriteString(levelBadge(record.Level))
+	buf.WriteByte(' ')
+	buf.WriteString(record.Message)
+
+	for _, g := range h.groups {
+		buf.WriteByte(' ')
+		buf.WriteString(g)
+		buf.WriteByte(':')
+	}
+	for _, a := range h.attrs {
+		fmt.Fprintf(&buf, " %s=%v", a.Key, a.Value)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(&buf, " %s=%v", a.Key, a.Value)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &next
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	next := *h
+	next.groups = append(append([]string{}, h.groups...), name)
+	return &next
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+	ansiGray   = "\x1b[90m"
+)
+
+func levelBadge(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return ansiRed + "ERROR" + ansiReset
+	case level >= slog.LevelWarn:
+		return ansiYellow + "WARN " + ansiReset
+	case level >= slog.LevelInfo:
+		return ansiBlue + "INFO " + ansiReset
+	default:
+		return ansiGray + "DEBUG" + ansiReset
+	}
+}
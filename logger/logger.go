@@ -10,47 +10,105 @@ import (
 
 var (
 	logger *slog.Logger
-	file   *os.File
+	file   *rotatingWriter
 )
 
-// Init initializes the logger with a file destination
-func Init() error {
-	// Create log directory if it doesn't exist
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get user home directory: %w", err)
+// Format selects how the file sink renders records.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatText
+)
+
+// Config controls rotation, retention, and the console mirror. Zero value
+// fields are replaced by DefaultConfig's values in Init.
+type Config struct {
+	// Dir overrides the log directory; defaults to ~/.config/yapper/logs.
+	Dir string
+
+	// MaxSizeBytes is the size threshold that triggers rotation. <= 0
+	// disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAge is how long a rotated backup is kept before deletion. <= 0
+	// disables age-based pruning.
+	MaxAge time.Duration
+	// MaxBackups caps how many rotated files are kept regardless of age.
+	// <= 0 disables the cap.
+	MaxBackups int
+	// Compress gzips a backup immediately after it's rotated out.
+	Compress bool
+
+	// Format selects JSON or plain text for the file sink.
+	Format Format
+	// FileLevel is the minimum level written to the file.
+	FileLevel slog.Level
+
+	// Console mirrors records to stderr in a colorized, human-oriented
+	// format, independent of the file sink.
+	Console bool
+	// ConsoleLevel is the minimum level mirrored to stderr.
+	ConsoleLevel slog.Level
+}
+
+// DefaultConfig matches the previous hard-coded behavior (JSON, debug
+// level, no rotation) plus sensible rotation/retention defaults and a
+// warn-level-and-up console mirror.
+func DefaultConfig() Config {
+	return Config{
+		MaxSizeBytes: 10 * 1024 * 1024, // 10MB
+		MaxAge:       7 * 24 * time.Hour,
+		MaxBackups:   5,
+		Compress:     true,
+		Format:       FormatJSON,
+		FileLevel:    slog.LevelDebug,
+		Console:      true,
+		ConsoleLevel: slog.LevelWarn,
 	}
+}
+
+// Init initializes the logger with DefaultConfig's settings.
+func Init() error {
+	return InitWithConfig(DefaultConfig())
+}
 
-	// Create logs directory in ~/.config/yapper/logs
-	logDir := filepath.Join(homeDir, ".config", "yapper", "logs")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("failed to create log directory: %w", err)
+// InitWithConfig initializes the logger with explicit rotation/retention
+// and console-mirroring settings.
+func InitWithConfig(cfg Config) error {
+	dir := cfg.Dir
+	if dir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		dir = filepath.Join(homeDir, ".config", "yapper", "logs")
 	}
 
-	// Create log file with timestamp in name
-	timestamp := time.Now().Format("2006-01-02")
-	logFilePath := filepath.Join(logDir, fmt.Sprintf("yapper-%s.log", timestamp))
-	
-	file, err = os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	rw, err := newRotatingWriter(dir, cfg.MaxSizeBytes, cfg.MaxAge, cfg.MaxBackups, cfg.Compress)
 	if err != nil {
-		return fmt.Errorf("failed to open log file: %w", err)
+		return err
 	}
+	file = rw
 
-	// Create JSON handler that writes to the file
-	handler := slog.NewJSONHandler(file, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-		AddSource: true,
-	})
+	handlerOpts := &slog.HandlerOptions{Level: cfg.FileLevel, AddSource: true}
+	var fileHandler slog.Handler
+	if cfg.Format == FormatText {
+		fileHandler = slog.NewTextHandler(file, handlerOpts)
+	} else {
+		fileHandler = slog.NewJSONHandler(file, handlerOpts)
+	}
 
-	// Create the logger
-	logger = slog.New(handler)
+	handlers := []slog.Handler{fileHandler}
+	if cfg.Console {
+		handlers = append(handlers, newConsoleHandler(os.Stderr, cfg.ConsoleLevel))
+	}
 
-	// Log that logger was initialized
-	logger.Info("logger initialized", "file", logFilePath)
+	logger = slog.New(newMultiHandler(handlers...))
+	logger.Info("logger initialized", "dir", dir)
 	return nil
 }
 
-// Close closes the log file
+// Close closes the log file.
 func Close() error {
 	if file != nil {
 		logger.Info("closing logger")
@@ -90,4 +148,4 @@ func Error(msg string, args ...any) {
 // GetLogger returns the slog.Logger instance
 func GetLogger() *slog.Logger {
 	return logger
-}
\ No newline at end of file
+}
@@ -0,0 +1,210 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatingWriter is an io.Writer backing the file sink. Once the current
+// file exceeds maxSize bytes it is closed, renamed to
+// yapper-YYYY-MM-DD.N.log, optionally gzipped in the background, and a
+// fresh file is opened in its place.
+type rotatingWriter struct {
+	mu   sync.Mutex
+	dir  string
+	file *os.File
+	size int64
+
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+}
+
+func newRotatingWriter(dir string, maxSize int64, maxAge time.Duration, maxBackups int, compress bool) (*rotatingWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating log directory: %w", err)
+	}
+	w := &rotatingWriter{
+		dir:        dir,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		compress:   compress,
+	}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	w.pruneBackups()
+	return w, nil
+}
+
+func (w *rotatingWriter) currentPath() string {
+	return filepath.Join(w.dir, "yapper-current.log")
+}
+
+func (w *rotatingWriter) openCurrent() error {
+	f, err := os.OpenFile(w.currentPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write implements io.Writer, rotating the underlying file first if p
+// would push it past maxSize.
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxSize > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("closing log file before rotation: %w", err)
+	}
+
+	rotatedPath := filepath.Join(w.dir, fmt.Sprintf("yapper-%s.%d.log", time.Now().Format("2006-01-02"), w.nextBackupIndex()))
+	if err := os.Rename(w.currentPath(), rotatedPath); err != nil {
+		return fmt.Errorf("renaming rotated log: %w", err)
+	}
+
+	if w.compress {
+		go compressAndRemove(rotatedPath)
+	}
+
+	if err := w.openCurrent(); err != nil {
+		return err
+	}
+	w.pruneBackups()
+	return nil
+}
+
+func (w *rotatingWriter) nextBackupIndex() int {
+	entries := w.listBackups()
+	max := 0
+	for _, e := range entries {
+		if e.index > max {
+			max = e.index
+		}
+	}
+	return max + 1
+}
+
+type backupFile struct {
+	path    string
+	index   int
+	modTime time.Time
+}
+
+// listBackups finds rotated log files (compressed or not) in dir.
+func (w *rotatingWriter) listBackups() []backupFile {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil
+	}
+	var backups []backupFile
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if !strings.HasPrefix(name, "yapper-") || name == "yapper-current.log" {
+			continue
+		}
+		trimmed := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".log")
+		parts := strings.Split(trimmed, ".")
+		if len(parts) < 2 {
+			continue
+		}
+		idx, err := strconv.Atoi(parts[len(parts)-1])
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{
+			path:    filepath.Join(w.dir, name),
+			index:   idx,
+			modTime: info.ModTime(),
+		})
+	}
+	return backups
+}
+
+// pruneBackups deletes rotated files older than maxAge or beyond
+// maxBackups, keeping the most recent ones.
+func (w *rotatingWriter) pruneBackups() {
+	backups := w.listBackups()
+	sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.After(backups[j].modTime) })
+
+	now := time.Now()
+	for i, b := range backups {
+		expired := w.maxAge > 0 && now.Sub(b.modTime) > w.maxAge
+		overflow := w.maxBackups > 0 && i >= w.maxBackups
+		if expired || overflow {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
+
+// compressAndRemove gzips path to path+".gz" and removes the original. Run
+// in its own goroutine so rotation never blocks on disk I/O for the old
+// file.
+func compressAndRemove(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return
+	}
+	if err := gw.Close(); err != nil {
+		return
+	}
+	_ = os.Remove(path)
+}